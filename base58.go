@@ -0,0 +1,57 @@
+package buid
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// base58Alphabet is the Bitcoin base58 alphabet
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// base58Len is the number of base58 digits to encode 128 bits
+	base58Len = 22
+)
+
+var (
+	big58     = big.NewInt(58)
+	maxBase58 = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+// Base58 returns the ID encoded in the Bitcoin base58 alphabet, left-padded
+// with '1' to a fixed width of 22 characters
+//
+// Unlike the base-62 string and MarshalBitcoin, leading zero bytes are not
+// compressed, so every ID has exactly one encoding of the same length.
+func (id ID) Base58() string {
+	var buf [base58Len]byte
+	n := new(big.Int).SetBytes(id[:])
+	m := new(big.Int)
+	for i := len(buf) - 1; i >= 0; i-- {
+		n.DivMod(n, big58, m)
+		buf[i] = base58Alphabet[m.Int64()]
+	}
+	return string(buf[:])
+}
+
+// IDFromBase58 parses a string returned by ID.Base58
+func IDFromBase58(s string) (ID, error) {
+	var id ID
+	if len(s) != base58Len {
+		return id, fmt.Errorf("base58 BUID must be %d characters, got %d", base58Len, len(s))
+	}
+	n := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base58Alphabet, s[i])
+		if d < 0 {
+			return id, fmt.Errorf("invalid base58 character %q at %d", s[i], i)
+		}
+		n.Mul(n, big58)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	if n.Cmp(maxBase58) >= 0 {
+		return id, fmt.Errorf("base58 BUID %q overflows 128 bits", s)
+	}
+	n.FillBytes(id[:])
+	return id, nil
+}
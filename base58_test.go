@@ -0,0 +1,56 @@
+package buid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBase58(t *testing.T) {
+	var max ID
+	for i := range max {
+		max[i] = 0xff
+	}
+	p := NewProcess(0xffff)
+	ids := []ID{{}, {15: 1}, max, p.NewID(0, time.Now()), p.NewID(0x8000, time.Now()), p.NewID(0xffff, time.Now())}
+	for _, id := range ids {
+		s := id.Base58()
+		if len(s) != 22 {
+			t.Fatalf("expect 22 characters got %d (%s)", len(s), s)
+		}
+		id2, err := IDFromBase58(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id2 != id {
+			t.Fatalf("expect %v got %v", id, id2)
+		}
+	}
+	if s, expected := (ID{}).Base58(), strings.Repeat("1", 22); s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+	if s, expected := (ID{15: 1}).Base58(), strings.Repeat("1", 21)+"2"; s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+	if s, expected := max.Base58(), "YcVfxkQb6JRzqk5kF2tNLv"; s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+}
+
+func TestBase58Error(t *testing.T) {
+	for _, s := range []string{
+		"",
+		strings.Repeat("1", 21),
+		strings.Repeat("1", 23),
+		strings.Repeat("1", 21) + "0",
+		strings.Repeat("1", 21) + "l",
+		strings.Repeat("z", 22),
+	} {
+		if _, err := IDFromBase58(s); err == nil {
+			t.Fatalf("expect error for %q", s)
+		}
+	}
+	if _, err := IDFromBase58(strings.Repeat("1", 21) + "0"); !strings.Contains(err.Error(), "invalid base58 character") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
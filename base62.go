@@ -0,0 +1,41 @@
+package buid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// base62Alphabet is the alphabet of the base-62 text encoding
+const base62Alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// appendBase62 appends the base-62 encoding of an 8 or 16 byte src to dst
+// without allocating, producing the same text as base62Encoding.Encode
+func appendBase62(dst, src []byte) []byte {
+	var hi, lo uint64
+	if len(src) == 16 {
+		hi = binary.BigEndian.Uint64(src[:8])
+		lo = binary.BigEndian.Uint64(src[8:])
+	} else {
+		lo = binary.BigEndian.Uint64(src)
+	}
+
+	// leading zero bytes are compressed to one zero digit each (except the
+	// last byte), the same as basex
+	for k := 0; k < len(src)-1 && src[k] == 0; k++ {
+		dst = append(dst, base62Alphabet[0])
+	}
+
+	var digits [22]byte
+	i := len(digits)
+	for {
+		var r uint64
+		hi, r = bits.Div64(0, hi, 62)
+		lo, r = bits.Div64(r, lo, 62)
+		i--
+		digits[i] = base62Alphabet[r]
+		if hi == 0 && lo == 0 {
+			break
+		}
+	}
+	return append(dst, digits[i:]...)
+}
@@ -0,0 +1,89 @@
+package buid
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestAppendBase62(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{8, 16} {
+		for i := 0; i < 10000; i++ {
+			src := make([]byte, n)
+			rnd.Read(src)
+			// exercise the compression of leading zero bytes
+			for j := 0; j < i%(n+1); j++ {
+				src[j] = 0
+			}
+			expected := base62Encoding.Encode(src)
+			if actual := string(appendBase62(nil, src)); actual != expected {
+				t.Fatalf("expect %s got %s for %x", expected, actual, src)
+			}
+		}
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	id := NewProcess(3).NewID(2, time.Now())
+	shard, key := id.Split()
+	for _, c := range []struct {
+		actual   []byte
+		expected string
+	}{
+		{id.AppendText([]byte("prefix:")), base62Encoding.Encode(id[:])},
+		{shard.AppendText([]byte("prefix:")), base62Encoding.Encode(shard[:])},
+		{key.AppendText([]byte("prefix:")), base62Encoding.Encode(key[:])},
+	} {
+		if expected := "prefix:" + c.expected; string(c.actual) != expected {
+			t.Fatalf("expect %s got %s", expected, c.actual)
+		}
+	}
+	if buf := (ID{}).AppendText([]byte("a")); !bytes.Equal(buf, []byte("a")) {
+		t.Fatalf("expect a got %s", buf)
+	}
+	buf := make([]byte, 0, 22)
+	if n := testing.AllocsPerRun(100, func() { buf = id.AppendText(buf[:0]) }); n != 0 {
+		t.Fatalf("expect 0 allocs got %v", n)
+	}
+}
+
+func BenchmarkAppendTextID(b *testing.B) {
+	id := NewProcess(3).NewID(2, time.Now())
+	buf := make([]byte, 0, 22)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = id.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkAppendTextKey(b *testing.B) {
+	_, key := NewProcess(3).NewID(2, time.Now()).Split()
+	buf := make([]byte, 0, 11)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = key.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkAppendTextShard(b *testing.B) {
+	shard, _ := NewProcess(3).NewID(2, time.Now()).Split()
+	buf := make([]byte, 0, 11)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = shard.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkMarshalTextID(b *testing.B) {
+	id := NewProcess(3).NewID(2, time.Now())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		id.MarshalText()
+	}
+}
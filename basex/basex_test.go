@@ -157,6 +157,20 @@ func Test_NonDecodable(t *testing.T) {
 	expect(err.Error(), "Non Base Character", t)
 }
 
+func Test_MultiByteAlphabet(t *testing.T) {
+	enc, _ := NewEncoding("αβγδ")
+	src := hex("00ff1234")
+	encoded := enc.Encode(src)
+	expect(encoded, "αδδδδαβαγαδβα", t)
+	dec, err := enc.Decode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(h.EncodeToString(dec), "00ff1234", t)
+	_, err = enc.Decode("αé")
+	expect(err.Error(), "Non Base Character", t)
+}
+
 func hex(in string) []byte {
 	dec, err := h.DecodeString(in)
 	if err != nil {
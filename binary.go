@@ -0,0 +1,143 @@
+package buid
+
+import (
+	"errors"
+	"io"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns a copy of the
+// raw bytes
+func (id ID) MarshalBinary() ([]byte, error) {
+	return append(make([]byte, 0, len(id)), id[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (id *ID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(id) {
+		return errors.New("BUID length must be 128 bit")
+	}
+	copy(id[:], data)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns a copy of the
+// raw bytes
+func (k Key) MarshalBinary() ([]byte, error) {
+	return append(make([]byte, 0, len(k)), k[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (k *Key) UnmarshalBinary(data []byte) error {
+	if len(data) != len(k) {
+		return errors.New("key length must be 64 bit")
+	}
+	copy(k[:], data)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns a copy of the
+// raw bytes
+func (s Shard) MarshalBinary() ([]byte, error) {
+	return append(make([]byte, 0, len(s)), s[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (s *Shard) UnmarshalBinary(data []byte) error {
+	if len(data) != len(s) {
+		return errors.New("shard length must be 64 bit")
+	}
+	copy(s[:], data)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder with the same bytes as MarshalBinary
+func (id ID) GobEncode() ([]byte, error) { return id.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder
+func (id *ID) GobDecode(data []byte) error { return id.UnmarshalBinary(data) }
+
+// GobEncode implements gob.GobEncoder with the same bytes as MarshalBinary
+func (k Key) GobEncode() ([]byte, error) { return k.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder
+func (k *Key) GobDecode(data []byte) error { return k.UnmarshalBinary(data) }
+
+// GobEncode implements gob.GobEncoder with the same bytes as MarshalBinary
+func (s Shard) GobEncode() ([]byte, error) { return s.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder
+func (s *Shard) GobDecode(data []byte) error { return s.UnmarshalBinary(data) }
+
+// ScanID reads exactly 16 bytes of an ID from r
+//
+// Like io.ReadFull, the error is io.EOF only if no bytes were read.
+func ScanID(r io.Reader) (ID, error) {
+	var id ID
+	_, err := io.ReadFull(r, id[:])
+	return id, err
+}
+
+// ScanKey reads exactly 8 bytes of a Key from r
+func ScanKey(r io.Reader) (Key, error) {
+	var k Key
+	_, err := io.ReadFull(r, k[:])
+	return k, err
+}
+
+// ScanShard reads exactly 8 bytes of a Shard from r
+func ScanShard(r io.Reader) (Shard, error) {
+	var s Shard
+	_, err := io.ReadFull(r, s[:])
+	return s, err
+}
+
+// WriteTo implements io.WriterTo and writes the raw 16 bytes to w
+func (id ID) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(id[:])
+	return int64(n), err
+}
+
+// ReadFrom implements io.ReaderFrom and reads exactly 16 bytes from r
+//
+// The error is the one returned by io.ReadFull and id is left unchanged on
+// error.
+func (id *ID) ReadFrom(r io.Reader) (int64, error) {
+	var buf ID
+	n, err := io.ReadFull(r, buf[:])
+	if err == nil {
+		*id = buf
+	}
+	return int64(n), err
+}
+
+// WriteTo implements io.WriterTo and writes the raw 8 bytes to w
+func (k Key) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(k[:])
+	return int64(n), err
+}
+
+// ReadFrom implements io.ReaderFrom and reads exactly 8 bytes from r
+func (k *Key) ReadFrom(r io.Reader) (int64, error) {
+	var buf Key
+	n, err := io.ReadFull(r, buf[:])
+	if err == nil {
+		*k = buf
+	}
+	return int64(n), err
+}
+
+// WriteTo implements io.WriterTo and writes the raw 8 bytes to w
+func (s Shard) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(s[:])
+	return int64(n), err
+}
+
+// ReadFrom implements io.ReaderFrom and reads exactly 8 bytes from r
+func (s *Shard) ReadFrom(r io.Reader) (int64, error) {
+	var buf Shard
+	n, err := io.ReadFull(r, buf[:])
+	if err == nil {
+		*s = buf
+	}
+	return int64(n), err
+}
@@ -0,0 +1,244 @@
+package buid
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"io"
+	"testing"
+	"time"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = ID{}
+	_ encoding.BinaryUnmarshaler = &ID{}
+	_ encoding.BinaryMarshaler   = Key{}
+	_ encoding.BinaryUnmarshaler = &Key{}
+	_ encoding.BinaryMarshaler   = Shard{}
+	_ encoding.BinaryUnmarshaler = &Shard{}
+	_ gob.GobEncoder             = ID{}
+	_ gob.GobDecoder             = &ID{}
+	_ gob.GobEncoder             = Key{}
+	_ gob.GobDecoder             = &Key{}
+	_ gob.GobEncoder             = Shard{}
+	_ gob.GobDecoder             = &Shard{}
+	_ io.WriterTo                = ID{}
+	_ io.ReaderFrom              = &ID{}
+	_ io.WriterTo                = Key{}
+	_ io.ReaderFrom              = &Key{}
+	_ io.WriterTo                = Shard{}
+	_ io.ReaderFrom              = &Shard{}
+)
+
+func TestBinary(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, key := id.Split()
+
+	data, err := id.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(id[:]) {
+		t.Fatalf("expect %x got %x", id[:], data)
+	}
+	data[0]++
+	if data[0] == id[0] {
+		t.Fatal("expect a copy of the ID bytes")
+	}
+	data[0]--
+	var id2 ID
+	if err := id2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+
+	data, _ = key.MarshalBinary()
+	var key2 Key
+	if err := key2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if key2 != key {
+		t.Fatalf("expect %v got %v", key, key2)
+	}
+
+	data, _ = shard.MarshalBinary()
+	var shard2 Shard
+	if err := shard2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if shard2 != shard {
+		t.Fatalf("expect %v got %v", shard, shard2)
+	}
+}
+
+func TestBinaryError(t *testing.T) {
+	var (
+		id    ID
+		key   Key
+		shard Shard
+	)
+	for _, data := range [][]byte{nil, make([]byte, 8), make([]byte, 17)} {
+		if err := id.UnmarshalBinary(data); err == nil {
+			t.Fatalf("expect error for length %d", len(data))
+		}
+	}
+	for _, data := range [][]byte{nil, make([]byte, 7), make([]byte, 16)} {
+		if err := key.UnmarshalBinary(data); err == nil {
+			t.Fatalf("expect error for length %d", len(data))
+		}
+		if err := shard.UnmarshalBinary(data); err == nil {
+			t.Fatalf("expect error for length %d", len(data))
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type event struct {
+		IDs   []ID
+		Shard Shard
+		Key   Key
+	}
+	p := NewProcess(2)
+	e1 := event{IDs: []ID{p.NewID(1, time.Now()), p.NewID(2, time.Now()), p.NewID(3, time.Now())}}
+	e1.Shard, e1.Key = e1.IDs[0].Split()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(e1); err != nil {
+		t.Fatal(err)
+	}
+	var e2 event
+	if err := gob.NewDecoder(&buf).Decode(&e2); err != nil {
+		t.Fatal(err)
+	}
+	if len(e2.IDs) != len(e1.IDs) {
+		t.Fatalf("expect %v got %v", e1.IDs, e2.IDs)
+	}
+	for i := range e1.IDs {
+		if e1.IDs[i] != e2.IDs[i] {
+			t.Fatalf("expect %v got %v", e1.IDs[i], e2.IDs[i])
+		}
+	}
+	if e1.Shard != e2.Shard || e1.Key != e2.Key {
+		t.Fatalf("expect %v got %v", e1, e2)
+	}
+}
+
+func TestGobDecodeError(t *testing.T) {
+	var (
+		id    ID
+		key   Key
+		shard Shard
+	)
+	if err := id.GobDecode(make([]byte, 15)); err == nil {
+		t.Fatal("expect error")
+	}
+	if err := key.GobDecode(make([]byte, 16)); err == nil {
+		t.Fatal("expect error")
+	}
+	if err := shard.GobDecode(nil); err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestScan(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, key := id.Split()
+	var buf bytes.Buffer
+	buf.Write(id[:])
+	buf.Write(shard[:])
+	buf.Write(key[:])
+	buf.Write(key[:4])
+
+	if id2, err := ScanID(&buf); err != nil || id2 != id {
+		t.Fatalf("expect %v got %v, %v", id, id2, err)
+	}
+	if shard2, err := ScanShard(&buf); err != nil || shard2 != shard {
+		t.Fatalf("expect %v got %v, %v", shard, shard2, err)
+	}
+	if key2, err := ScanKey(&buf); err != nil || key2 != key {
+		t.Fatalf("expect %v got %v, %v", key, key2, err)
+	}
+	if _, err := ScanKey(&buf); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expect %v got %v", io.ErrUnexpectedEOF, err)
+	}
+	if _, err := ScanID(&buf); err != io.EOF {
+		t.Fatalf("expect %v got %v", io.EOF, err)
+	}
+}
+
+func TestWriteToReadFrom(t *testing.T) {
+	p := NewProcess(2)
+	ids := []ID{p.NewID(1, time.Now()), p.NewID(3, time.Now())}
+	r, w := io.Pipe()
+	go func() {
+		for _, id := range ids {
+			shard, key := id.Split()
+			for _, wt := range []io.WriterTo{id, shard, key} {
+				if _, err := wt.WriteTo(w); err != nil {
+					w.CloseWithError(err)
+					return
+				}
+			}
+		}
+		w.Close()
+	}()
+	for _, expected := range ids {
+		var (
+			id    ID
+			shard Shard
+			key   Key
+		)
+		for _, c := range []struct {
+			rf io.ReaderFrom
+			n  int64
+		}{{&id, 16}, {&shard, 8}, {&key, 8}} {
+			n, err := c.rf.ReadFrom(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != c.n {
+				t.Fatalf("expect %d got %d", c.n, n)
+			}
+		}
+		if id != expected {
+			t.Fatalf("expect %v got %v", expected, id)
+		}
+		if s, k := expected.Split(); shard != s || key != k {
+			t.Fatalf("expect %v %v got %v %v", s, k, shard, key)
+		}
+	}
+	var id ID
+	if n, err := id.ReadFrom(r); err != io.EOF || n != 0 {
+		t.Fatalf("expect 0 %v got %d %v", io.EOF, n, err)
+	}
+}
+
+func TestReadFromShort(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	r, w := io.Pipe()
+	go func() {
+		w.Write(id[:10])
+		w.Close()
+	}()
+	var actual ID
+	n, err := actual.ReadFrom(r)
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("expect %v got %v", io.ErrUnexpectedEOF, err)
+	}
+	if n != 10 {
+		t.Fatalf("expect 10 got %d", n)
+	}
+	if !actual.IsZero() {
+		t.Fatalf("expect zero got %v", actual)
+	}
+
+	var key Key
+	if _, err := key.ReadFrom(bytes.NewReader(id[:7])); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expect %v got %v", io.ErrUnexpectedEOF, err)
+	}
+	var shard Shard
+	if _, err := shard.ReadFrom(bytes.NewReader(nil)); err != io.EOF {
+		t.Fatalf("expect %v got %v", io.EOF, err)
+	}
+}
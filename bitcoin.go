@@ -0,0 +1,53 @@
+package buid
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+
+	"h12.io/buid/basex"
+)
+
+// bitcoinVersion is the version byte prefixed to a base58check encoded ID
+const bitcoinVersion = 0x80
+
+var base58Encoding, _ = basex.NewEncoding(base58Alphabet)
+
+// MarshalBitcoin returns the ID encoded as base58check like the Bitcoin Wallet
+// Import Format: the version byte 0x80, the 16 ID bytes and the first 4 bytes
+// of the double SHA-256 of both
+func (id ID) MarshalBitcoin() string {
+	buf := make([]byte, 0, 1+len(id)+4)
+	buf = append(buf, bitcoinVersion)
+	buf = append(buf, id[:]...)
+	buf = append(buf, bitcoinChecksum(buf)...)
+	return base58Encoding.Encode(buf)
+}
+
+// UnmarshalBitcoin parses a string returned by MarshalBitcoin and verifies its
+// version byte and checksum
+func UnmarshalBitcoin(s string) (ID, error) {
+	var id ID
+	buf, err := base58Encoding.Decode(s)
+	if err != nil {
+		return id, err
+	}
+	if len(buf) != 1+len(id)+4 {
+		return id, errors.New("invalid base58check length")
+	}
+	if buf[0] != bitcoinVersion {
+		return id, errors.New("invalid base58check version")
+	}
+	payload, checksum := buf[:1+len(id)], buf[1+len(id):]
+	if !bytes.Equal(bitcoinChecksum(payload), checksum) {
+		return id, errors.New("invalid base58check checksum")
+	}
+	copy(id[:], payload[1:])
+	return id, nil
+}
+
+func bitcoinChecksum(payload []byte) []byte {
+	h := sha256.Sum256(payload)
+	h = sha256.Sum256(h[:])
+	return h[:4]
+}
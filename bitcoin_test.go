@@ -0,0 +1,53 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBitcoin(t *testing.T) {
+	for _, id := range []ID{{}, NewProcess(2).NewID(1, time.Now())} {
+		s := id.MarshalBitcoin()
+		id2, err := UnmarshalBitcoin(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id2 != id {
+			t.Fatalf("expect %v got %v", id, id2)
+		}
+	}
+}
+
+func TestBitcoinKnownValue(t *testing.T) {
+	var id ID
+	for i := range id {
+		id[i] = byte(i)
+	}
+	if s, expected := id.MarshalBitcoin(), "8sWmykwPRCRjGtuBczJSJTysdzuaW"; s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+}
+
+func TestBitcoinError(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	s := id.MarshalBitcoin()
+
+	// flip the last character to break the checksum
+	last := s[len(s)-1]
+	if last == '1' {
+		last = '2'
+	} else {
+		last = '1'
+	}
+	for _, s := range []string{
+		"",
+		"0OIl",
+		s[:len(s)-1] + string(last),
+		base58Encoding.Encode(append([]byte{0x81}, id[:]...)),
+		base58Encoding.Encode(id[:]),
+	} {
+		if _, err := UnmarshalBitcoin(s); err == nil {
+			t.Fatalf("expect error for %q", s)
+		}
+	}
+}
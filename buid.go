@@ -19,7 +19,7 @@ shard:
     0             1               2               3
     7 6 5 4 3 2 1 0 7 6 5 4 3 2 1 0 7 6 5 4 3 2 1 0 7 6 5 4 3 2 1 0
    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-   |           shard-hash          |            reserved           |
+   |           shard-hash          |      namespace (reserved)     |
    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
    |                  hours (from bespoke epoch)                   |
    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
@@ -39,6 +39,7 @@ Definitions:
    | item        | type   | description                                                         |
    |-------------|--------|---------------------------------------------------------------------|
    | shard-hash  | uint16 | a hash code for a shard for storing the data associated to the BUID |
+   | namespace   | uint16 | optional application-defined subtype, zero by default               |
    | hours       | uint32 | hours from bespoke epoch (490,293 years, should be enough :-)       |
    | minutes     | uint6  | 0-59 minutes within an hour                                         |
    | seconds     | uint6  | 0-59 seconds within a minute                                        |
@@ -48,11 +49,19 @@ Definitions:
 
 */
 //go:generate msgp
+//msgp:ignore Process
 package buid
 
 import (
 	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"h12.io/buid/basex"
@@ -71,7 +80,11 @@ type (
 		id      uint16
 		t       int64
 		counter uint8
+		clock   func() time.Time
 		mu      sync.Mutex
+
+		maxFuture  time.Duration
+		contention atomic.Uint64
 	}
 )
 
@@ -85,6 +98,23 @@ const (
 // Epoch is the bespoke epoch of BUID in Unix Epoch in nanoseconds
 var Epoch = time.Date(2017, 10, 24, 0, 0, 0, 0, time.UTC).UnixNano()
 
+// MaxFuture is the default of how far ahead of now a timestamp passed to
+// NewIDAtUnixNano may be
+const MaxFuture = 10 * 365 * 24 * time.Hour
+
+var (
+	// ErrBeforeEpoch is returned when a timestamp is before Epoch
+	ErrBeforeEpoch = errors.New("timestamp is before BUID epoch")
+	// ErrFutureTooFar is returned when a timestamp is too far in the future,
+	// MaxFuture ahead by default
+	ErrFutureTooFar = errors.New("timestamp is too far in the future")
+	// ErrOutOfRange is returned when the current time is outside of the
+	// requested time range
+	ErrOutOfRange = errors.New("current time is out of range")
+	// ErrExpiredID is returned when an ID is older than the max age
+	ErrExpiredID = errors.New("BUID expired")
+)
+
 // internalTime returns internal epoch time in nanoseconds
 func internalTime(t time.Time) int64 {
 	return t.UnixNano() - Epoch
@@ -95,40 +125,287 @@ func externalTime(t int64) time.Time {
 
 // NewProcess returns a new Process object for id
 func NewProcess(id uint16) *Process {
-	// the internal time is added by a nanosecond to avoid
-	// possible conflict caused by restarting within a nanosecond
-	// (though not likely)
+	return NewProcessWithOptions(WithProcessID(id))
+}
+
+// ProcessOption configures a Process created by NewProcessWithOptions
+type ProcessOption func(*processOptions)
+
+type processOptions struct {
+	id        uint16
+	clock     func() time.Time
+	counter   uint8
+	t         *time.Time
+	maxFuture time.Duration
+}
+
+// WithProcessID sets the process ID, which is zero by default
+func WithProcessID(id uint16) ProcessOption {
+	return func(o *processOptions) { o.id = id }
+}
+
+// WithClock sets the clock of the current time, which is time.Now by default
+//
+// It is used by NewIDNow and when waiting for the time to proceed after the
+// counter overflows, so a test can inject a clock that advances without
+// sleeping.
+func WithClock(clock func() time.Time) ProcessOption {
+	return func(o *processOptions) { o.clock = clock }
+}
+
+// WithInitialCounter sets the initial counter, which is zero by default
+func WithInitialCounter(n uint8) ProcessOption {
+	return func(o *processOptions) { o.counter = n }
+}
+
+// WithInitialTime sets the initial internal time, which is a nanosecond after
+// the current time by default
+func WithInitialTime(t time.Time) ProcessOption {
+	return func(o *processOptions) { o.t = &t }
+}
+
+// WithMaxFuture sets how far ahead of now a timestamp passed to
+// NewIDAtUnixNano may be, which is MaxFuture by default
+func WithMaxFuture(d time.Duration) ProcessOption {
+	return func(o *processOptions) { o.maxFuture = d }
+}
+
+// NewProcessWithOptions returns a new Process object configured by opts
+func NewProcessWithOptions(opts ...ProcessOption) *Process {
+	o := processOptions{clock: time.Now, maxFuture: MaxFuture}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var t int64
+	if o.t != nil {
+		t = internalTime(*o.t)
+	} else {
+		// the internal time is added by a nanosecond to avoid
+		// possible conflict caused by restarting within a nanosecond
+		// (though not likely)
+		t = internalTime(o.clock().Add(time.Nanosecond))
+	}
 	return &Process{
-		id: id,
-		t:  internalTime(time.Now().Add(time.Nanosecond)),
+		id:        o.id,
+		t:         t,
+		counter:   o.counter,
+		clock:     o.clock,
+		maxFuture: o.maxFuture,
+	}
+}
+
+// now returns the current time of the process clock
+func (p *Process) now() time.Time {
+	if p.clock == nil {
+		return time.Now()
+	}
+	return p.clock()
+}
+
+// NewProcessFromHostPID returns a new Process object with an ID derived from
+// the hostname and the OS process ID, for deployments without a reliable way
+// to assign one
+//
+// The ID is repeatable within a process, but two processes may still collide
+// since it is only 16 bits.
+func NewProcessFromHostPID() (*Process, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, err
 	}
+	return NewProcess(processIDFromHostPID(host, os.Getpid())), nil
+}
+
+// processIDFromHostPID folds the FNV-32a hash of host and pid into 16 bits
+func processIDFromHostPID(host string, pid int) uint16 {
+	h := fnv.New32a()
+	h.Write([]byte(host + strconv.Itoa(pid)))
+	sum := h.Sum32()
+	return uint16(sum>>16) ^ uint16(sum)
+}
+
+// EnsureMonotonicity checks that the internal time of a restored Process is
+// reasonable compared with the wall clock: not more than 1 hour in the future
+// (the state is from the future) and not more than 24 hours in the past (the
+// state is stale)
+func (p *Process) EnsureMonotonicity() error {
+	p.mu.Lock()
+	t := externalTime(p.t)
+	p.mu.Unlock()
+	now := p.now()
+	if d := t.Sub(now); d > time.Hour {
+		return fmt.Errorf("process time %v is %v ahead of now, the state may be from the future", t, d)
+	}
+	if d := now.Sub(t); d > 24*time.Hour {
+		return fmt.Errorf("process time %v is %v behind now, the state may be stale", t, d)
+	}
+	return nil
 }
 
 // NewID generates a new BUID from a shard index and a timestamp
-func (p *Process) NewID(shard uint16, timestamp time.Time) ID {
+func (p *Process) NewID(shard uint16, timestamp time.Time) (id ID) {
+	ts := internalTime(timestamp)
+	p.mu.Lock()
+	t, counter := p.next(ts)
+	p.mu.Unlock()
+	p.makeID(&id, shard, t, counter)
+	return id
+}
+
+// NewIDNoWait is like NewID but returns false immediately instead of waiting
+// for the time to proceed if the counter would overflow, leaving the retry to
+// the caller
+func (p *Process) NewIDNoWait(shard uint16, timestamp time.Time) (id ID, ok bool) {
 	ts := internalTime(timestamp)
+	p.mu.Lock()
+	if ts <= p.t && p.counter > maxCounter {
+		p.mu.Unlock()
+		return ID{}, false
+	}
+	t, counter := p.next(ts)
+	p.mu.Unlock()
+	p.makeID(&id, shard, t, counter)
+	return id, true
+}
+
+// NewIDNow generates a new BUID from a shard index and the current time of the
+// process clock
+func (p *Process) NewIDNow(shard uint16) ID {
+	return p.NewID(shard, p.now())
+}
 
+// NewIDAtUnixNano generates a new BUID from a shard index and a timestamp in
+// Unix nanoseconds
+//
+// It returns ErrBeforeEpoch if the timestamp is before Epoch and
+// ErrFutureTooFar if it is too far ahead of now, see WithMaxFuture.
+func (p *Process) NewIDAtUnixNano(shard uint16, unixNano int64) (ID, error) {
+	if unixNano < Epoch {
+		return ID{}, ErrBeforeEpoch
+	}
+	if unixNano-p.now().UnixNano() > int64(p.maxFuture) {
+		return ID{}, ErrFutureTooFar
+	}
+	return p.NewID(shard, time.Unix(0, unixNano)), nil
+}
+
+// NewIDWithNamespace generates a new BUID from a shard index, a namespace and a
+// timestamp
+//
+// It returns ErrBeforeEpoch if the timestamp is before Epoch.
+func (p *Process) NewIDWithNamespace(shard, ns uint16, t time.Time) (ID, error) {
+	if internalTime(t) < 0 {
+		return ID{}, ErrBeforeEpoch
+	}
+	return p.NewID(shard, t).SetNamespace(ns), nil
+}
+
+// NewIDs generates n BUIDs from a shard index and a timestamp, taking the lock
+// only once
+//
+// The IDs are unique and their timestamps are monotonically non-decreasing. It
+// returns nil if n <= 0.
+func (p *Process) NewIDs(shard uint16, start time.Time, n int) []ID {
+	if n <= 0 {
+		return nil
+	}
+	ids := make([]ID, n)
+	ts := internalTime(start)
+	p.mu.Lock()
+	for i := range ids {
+		t, counter := p.next(ts)
+		p.makeID(&ids[i], shard, t, counter)
+	}
+	p.mu.Unlock()
+	return ids
+}
+
+// NewIDInRange generates a new BUID from a shard index and the current time
+//
+// It returns ErrOutOfRange unless start <= now <= end, where now is the current
+// time of the process clock, e.g. to make sure a backdated record is generated
+// within its business time window.
+func (p *Process) NewIDInRange(shard uint16, start, end time.Time) (ID, error) {
+	now := p.now()
+	if now.Before(start) || now.After(end) {
+		return ID{}, ErrOutOfRange
+	}
+	return p.NewID(shard, now), nil
+}
+
+// NewIDSlice generates a BUID for each timestamp of times, taking the lock only
+// once
+//
+// The timestamps are sorted first to minimize counter resets, so the IDs are
+// returned in timestamp order rather than the order of times, which is left
+// unmodified.
+func (p *Process) NewIDSlice(shard uint16, times []time.Time) []ID {
+	if len(times) == 0 {
+		return nil
+	}
+	sorted := make([]int64, len(times))
+	for i, t := range times {
+		sorted[i] = internalTime(t)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	ids := make([]ID, len(sorted))
+	p.mu.Lock()
+	for i, ts := range sorted {
+		t, counter := p.next(ts)
+		p.makeID(&ids[i], shard, t, counter)
+	}
+	p.mu.Unlock()
+	return ids
+}
+
+// next advances the process to internal time ts and returns the time and
+// counter of the next BUID, p.mu must be held
+func (p *Process) next(ts int64) (int64, uint16) {
 	// The implementation tries its best to avoid duplication:
 	// 1. When p.t is in a fixed nanosecond, counter increases
 	// 2. When p.t proceeds, counter resets
 	// 3. When counter overflowed, wait until p.t can be updated to a later time
 	// 4. Internal p.t never rewinds
-	p.mu.Lock()
-	for {
-		if ts > p.t {
-			p.t = ts
-			p.counter = 0
-		} else if p.counter > maxCounter {
-			ts = internalTime(time.Now())
-			continue
+	if ts > p.t {
+		p.t = ts
+		p.counter = 0
+	}
+	p.increase()
+	return p.t, uint16(p.counter - 1)
+}
+
+// increase increases the counter, waiting for p.t to proceed and resetting the
+// counter first if it overflowed, p.mu must be held. It is kept out of next so
+// that next stays inlinable.
+func (p *Process) increase() {
+	if p.counter > maxCounter {
+		p.contention.Add(1)
+		ts := internalTime(p.now())
+		for ts <= p.t {
+			ts = internalTime(p.now())
 		}
-		break
+		p.t = ts
+		p.counter = 0
 	}
-	t := p.t
-	counter := uint16(p.counter)
 	p.counter++
-	p.mu.Unlock()
+}
+
+// ContentionCount returns how many times the process has waited for the time
+// to proceed after the counter overflowed, e.g. to detect an overloaded
+// generator. It never blocks on the generation of IDs.
+func (p *Process) ContentionCount() uint64 {
+	return p.contention.Load()
+}
+
+// ResetContentionCount resets ContentionCount to zero, e.g. at the start of
+// each monitoring window
+func (p *Process) ResetContentionCount() {
+	p.contention.Store(0)
+}
 
+// makeID packs the fields of a BUID into id, which lets NewID pack the bytes
+// right into its result instead of copying them through another frame
+func (p *Process) makeID(id *ID, shard uint16, t int64, counter uint16) {
 	var (
 		hour    = uint32(t / hourInNano)
 		minute  = uint8((t % hourInNano) / minuteInNano)
@@ -137,41 +414,158 @@ func (p *Process) NewID(shard uint16, timestamp time.Time) ID {
 		process = p.id
 	)
 
+	// shard
+	id[0], id[1] = byte(shard>>8), byte(shard)
+	id[2], id[3] = 0, 0 // namespace
+	id[4], id[5], id[6], id[7] = byte(hour>>24), byte(hour>>16), byte(hour>>8), byte(hour)
+
+	// key
+	id[8] = ((minute & 0x3f) << 2) | ((second & 0x30) >> 4)
+	id[9] = ((second & 0x0f) << 4) | byte(nano>>26)
+	id[10], id[11] = byte(nano>>18), byte(nano>>10)
+	id[12], id[13] = byte(nano>>2), byte(nano<<6)|byte(counter)
+	id[14], id[15] = byte(process>>8), byte(process)
+}
+
+// IDFields contains all embedded fields of a BUID
+type IDFields struct {
+	ShardIndex uint16
+	Namespace  uint16
+	Hour       uint32
+	Minute     uint8
+	Second     uint8
+	Nanosecond uint32
+	Counter    uint16
+	Process    uint16
+}
+
+// Fields extracts all embedded fields at once
+func (id ID) Fields() IDFields {
+	return IDFields{
+		ShardIndex: (uint16(id[0]) << 8) | uint16(id[1]),
+		Namespace:  (uint16(id[2]) << 8) | uint16(id[3]),
+		Hour: (uint32(id[4]) << 24) |
+			(uint32(id[5]) << 16) |
+			(uint32(id[6]) << 8) |
+			uint32(id[7]),
+		Minute: (id[8] & 0xfc) >> 2,
+		Second: ((id[8] & 0x03) << 4) | (id[9] >> 4),
+		Nanosecond: (uint32(id[9]&0x0f) << 26) |
+			(uint32(id[10]) << 18) |
+			(uint32(id[11]) << 10) |
+			(uint32(id[12]) << 2) |
+			(uint32(id[13]) >> 6),
+		Counter: uint16(id[13] & 0x3f),
+		Process: (uint16(id[14]) << 8) | uint16(id[15]),
+	}
+}
+
+// ID packs the fields into a BUID
+func (f IDFields) ID() ID {
 	return ID{
 		// shard
-		byte(shard >> 8), byte(shard),
-		0, 0, // reserved
-		byte(hour >> 24), byte(hour >> 16), byte(hour >> 8), byte(hour),
+		byte(f.ShardIndex >> 8), byte(f.ShardIndex),
+		byte(f.Namespace >> 8), byte(f.Namespace),
+		byte(f.Hour >> 24), byte(f.Hour >> 16), byte(f.Hour >> 8), byte(f.Hour),
 
 		// key
-		((minute & 0x3f) << 2) | ((second & 0x30) >> 4),
-		((second & 0x0f) << 4) | byte(nano>>26),
-		byte(nano >> 18), byte(nano >> 10),
-		byte(nano >> 2), byte(nano<<6) | byte(counter),
-		byte(process >> 8), byte(process),
+		((f.Minute & 0x3f) << 2) | ((f.Second & 0x30) >> 4),
+		((f.Second & 0x0f) << 4) | byte(f.Nanosecond>>26),
+		byte(f.Nanosecond >> 18), byte(f.Nanosecond >> 10),
+		byte(f.Nanosecond >> 2), byte(f.Nanosecond<<6) | byte(f.Counter&maxCounter),
+		byte(f.Process >> 8), byte(f.Process),
 	}
 }
 
+// nanos returns the internal time in nanoseconds
+func (f IDFields) nanos() int64 {
+	return int64(f.Hour)*hourInNano +
+		int64(f.Minute)*minuteInNano +
+		int64(f.Second)*secondInNano +
+		int64(f.Nanosecond)
+}
+
+// setNanos sets the time fields from the internal time in nanoseconds
+func (f *IDFields) setNanos(t int64) {
+	f.Hour = uint32(t / hourInNano)
+	f.Minute = uint8((t % hourInNano) / minuteInNano)
+	f.Second = uint8((t % minuteInNano) / secondInNano)
+	f.Nanosecond = uint32(t % secondInNano)
+}
+
 // Time returns the embedded timestamp
 func (id ID) Time() time.Time {
-	var (
-		hour = (uint32(id[4]) << 24) |
-			(uint32(id[5]) << 16) |
-			(uint32(id[6]) << 8) |
-			uint32(id[7])
-		minute = (id[8] & 0xfc) >> 2
-		second = ((id[8] & 0x03) << 4) | (id[9] >> 4)
-		nano   = (uint32(id[9]&0x0f) << 26) |
-			(uint32(id[10]) << 18) |
-			(uint32(id[11]) << 10) |
-			(uint32(id[12]) << 2) |
-			(uint32(id[13]) >> 6)
-		t = int64(hour)*hourInNano +
-			int64(minute)*minuteInNano +
-			int64(second)*secondInNano +
-			int64(nano)
-	)
-	return externalTime(t)
+	return externalTime(id.Fields().nanos())
+}
+
+// TimeResidual returns the embedded time within the hour, the same as the
+// time of the key part
+func (id ID) TimeResidual() time.Duration {
+	f := id.Fields()
+	return time.Duration(int64(f.Minute)*minuteInNano +
+		int64(f.Second)*secondInNano +
+		int64(f.Nanosecond))
+}
+
+// TimeHour returns the embedded timestamp truncated to the hour, the same as
+// the time of the shard part
+func (id ID) TimeHour() time.Time {
+	return externalTime(int64(id.Fields().Hour) * hourInNano)
+}
+
+// VerifyAging returns an error wrapping ErrExpiredID if the age of the ID
+// exceeds maxAge, e.g. for a time-bounded token
+func (id ID) VerifyAging(maxAge time.Duration) error {
+	return id.VerifyAgingAt(time.Now(), maxAge)
+}
+
+// VerifyAgingAt is like VerifyAging but with the age measured at ref
+func (id ID) VerifyAgingAt(ref time.Time, maxAge time.Duration) error {
+	t := id.Time()
+	if expires := t.Add(maxAge); ref.After(expires) {
+		return fmt.Errorf("%w: issued at %v, expired at %v", ErrExpiredID, t, expires)
+	}
+	return nil
+}
+
+// TimeIn returns the embedded timestamp in loc
+func (id ID) TimeIn(loc *time.Location) time.Time {
+	return id.Time().In(loc)
+}
+
+// LocalTime returns the embedded timestamp in the local time zone
+func (id ID) LocalTime() time.Time {
+	return id.Time().Local()
+}
+
+// FormatTime returns the embedded timestamp in loc formatted with layout
+func (id ID) FormatTime(layout string, loc *time.Location) string {
+	return id.TimeIn(loc).Format(layout)
+}
+
+// Age returns the time elapsed since the embedded timestamp
+func (id ID) Age() time.Duration {
+	return time.Since(id.Time())
+}
+
+// OlderThan returns whether the age of the ID exceeds d, e.g. to expire a
+// cached entry
+func (id ID) OlderThan(d time.Duration) bool {
+	return id.Age() > d
+}
+
+// Distance returns the absolute difference between the embedded timestamps of
+// id and other, or math.MaxInt64 if it overflows
+func (id ID) Distance(other ID) time.Duration {
+	hours := int64(id.Fields().Hour) - int64(other.Fields().Hour)
+	residual := id.TimeResidual() - other.TimeResidual()
+	if hours < 0 {
+		hours, residual = -hours, -residual
+	}
+	if hours > (math.MaxInt64-hourInNano)/hourInNano {
+		return math.MaxInt64
+	}
+	return (time.Duration(hours*hourInNano) + residual).Abs()
 }
 
 // Shard returns the embedded shard index
@@ -189,6 +583,58 @@ func (id ID) Counter() uint16 {
 	return uint16(id[13] & 0x3f)
 }
 
+// Namespace returns the embedded namespace from the reserved bytes
+func (id ID) Namespace() uint16 {
+	return (uint16(id[2]) << 8) | uint16(id[3])
+}
+
+// SetNamespace returns a copy of the ID with the namespace set to ns
+//
+// The namespace takes all 16 reserved bits, so any other use of the reserved
+// bytes, e.g. a type tag, must be allocated within the namespace.
+func (id ID) SetNamespace(ns uint16) ID {
+	id[2], id[3] = byte(ns>>8), byte(ns)
+	return id
+}
+
+// MaskShard returns a copy of the ID with the shard index zeroed, to redact the
+// routing topology in user-facing logs
+func (id ID) MaskShard() ID {
+	return id.WithShard(0)
+}
+
+// MaskProcess returns a copy of the ID with the process zeroed, to avoid
+// fingerprinting servers in user-facing logs
+func (id ID) MaskProcess() ID {
+	return id.WithProcess(0)
+}
+
+// WithShard returns a copy of the ID with the shard index replaced, e.g. after
+// a shard migration
+func (id ID) WithShard(shard uint16) ID {
+	id[0], id[1] = byte(shard>>8), byte(shard)
+	return id
+}
+
+// WithProcess returns a copy of the ID with the process replaced
+func (id ID) WithProcess(process uint16) ID {
+	id[14], id[15] = byte(process>>8), byte(process)
+	return id
+}
+
+// SpreadAcrossShards returns shardCount copies of the ID, one for each shard
+// index from 0 to shardCount-1, for writing to multiple shards for redundancy
+//
+// The copies differ only in the shard index bytes.
+func (id ID) SpreadAcrossShards(shardCount uint16) []ID {
+	ids := make([]ID, shardCount)
+	for i := range ids {
+		ids[i] = id
+		ids[i][0], ids[i][1] = byte(i>>8), byte(i)
+	}
+	return ids
+}
+
 // Split splits BUID to Shard and Key
 func (id ID) Split() (Shard, Key) {
 	var shard Shard
@@ -198,7 +644,79 @@ func (id ID) Split() (Shard, Key) {
 	return shard, key
 }
 
-var base62Encoding, _ = basex.NewEncoding("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+// ShardPart returns the shard part of the ID, use Shard for the shard index
+func (id ID) ShardPart() Shard {
+	return Shard(id[:8])
+}
+
+// Key returns the key part of the ID
+func (id ID) Key() Key {
+	return Key(id[8:])
+}
+
+// EqualsShard returns whether the shard part of the ID is s without splitting
+// the ID
+func (id ID) EqualsShard(s Shard) bool {
+	return *(*Shard)(id[:8]) == s
+}
+
+// EqualsKey returns whether the key part of the ID is k without splitting the
+// ID
+func (id ID) EqualsKey(k Key) bool {
+	return *(*Key)(id[8:]) == k
+}
+
+// ToNetworkByteOrder returns the ID in network byte order (big-endian),
+// identical to the internal representation
+func (id ID) ToNetworkByteOrder() [16]byte {
+	return id
+}
+
+// FromNetworkByteOrder returns the ID of b in network byte order (big-endian)
+func FromNetworkByteOrder(b [16]byte) ID {
+	return ID(b)
+}
+
+// AsKey returns a pointer to the underlying array of the ID without copying
+//
+// The pointer aliases id: any change through it changes id and vice versa.
+// A map keyed by *[16]byte compares pointers, not contents, so it only finds
+// entries inserted with the very same pointer; comparing contents through the
+// pointer requires an unsafe conversion that the caller is responsible for.
+func (id *ID) AsKey() *[16]byte {
+	return (*[16]byte)(id)
+}
+
+// BitAt returns the bit at position [0, 127], where 0 is the MSB of byte 0 and
+// 127 is the LSB of byte 15
+//
+// It panics if position is out of range.
+func (id ID) BitAt(position int) bool {
+	checkBitPosition(position)
+	return id[position/8]&(0x80>>uint(position%8)) != 0
+}
+
+// SetBit returns a copy of the ID with the bit at position [0, 127] set to value
+//
+// It panics if position is out of range.
+func (id ID) SetBit(position int, value bool) ID {
+	checkBitPosition(position)
+	mask := byte(0x80 >> uint(position%8))
+	if value {
+		id[position/8] |= mask
+	} else {
+		id[position/8] &^= mask
+	}
+	return id
+}
+
+func checkBitPosition(position int) {
+	if position < 0 || position >= len(ID{})*8 {
+		panic(fmt.Sprintf("buid: bit position %d out of range [0, 127]", position))
+	}
+}
+
+var base62Encoding, _ = basex.NewEncoding(base62Alphabet)
 
 // IsZero returns whether or not the ID is initialized
 func (id ID) IsZero() bool { return id == ID{} }
@@ -208,7 +726,16 @@ func (id ID) MarshalText() (text []byte, err error) {
 	if id.IsZero() {
 		return nil, nil
 	}
-	return []byte(base62Encoding.Encode(id[:])), nil
+	return id.AppendText(make([]byte, 0, 22)), nil
+}
+
+// AppendText appends the base-62 encoded text to buf and returns the extended
+// buffer, without allocating if buf has enough capacity
+func (id ID) AppendText(buf []byte) []byte {
+	if id.IsZero() {
+		return buf
+	}
+	return appendBase62(buf, id[:])
 }
 
 // UnmarshalText unmarshals from hexidicmal encoded text
@@ -233,6 +760,41 @@ func (id ID) String() string {
 	return string(text)
 }
 
+// ParseID parses a base-62 encoded ID string
+func ParseID(s string) (ID, error) {
+	if s == "" {
+		return ID{}, errors.New("empty BUID string")
+	}
+	var id ID
+	if err := id.UnmarshalText([]byte(s)); err != nil {
+		return ID{}, err
+	}
+	return id, nil
+}
+
+// ToShardLocalString returns the base-62 encoded key part only, for use in a
+// shard-local table where the shard part is redundant
+//
+// Unlike Key.String, a zero key is encoded explicitly as "00000000", so that
+// every ID can be reconstructed by NewIDFromShardLocalString.
+func (id ID) ToShardLocalString() string {
+	_, key := id.Split()
+	return string(appendBase62(make([]byte, 0, 11), key[:]))
+}
+
+// NewIDFromShardLocalString reconstructs the ID from a string returned by
+// ToShardLocalString and the shard it is stored in
+func NewIDFromShardLocalString(s string, shard Shard) (ID, error) {
+	if s == "" {
+		return ID{}, errors.New("empty shard-local string")
+	}
+	var key Key
+	if err := key.UnmarshalText([]byte(s)); err != nil {
+		return ID{}, err
+	}
+	return Join(shard, key), nil
+}
+
 // IsZero returns whether or not the ID is initialized
 func (id Key) IsZero() bool { return id == Key{} }
 
@@ -241,7 +803,16 @@ func (id Key) MarshalText() (text []byte, err error) {
 	if id.IsZero() {
 		return nil, nil
 	}
-	return []byte(base62Encoding.Encode(id[:])), nil
+	return id.AppendText(make([]byte, 0, 11)), nil
+}
+
+// AppendText appends the base-62 encoded text to buf and returns the extended
+// buffer, without allocating if buf has enough capacity
+func (id Key) AppendText(buf []byte) []byte {
+	if id.IsZero() {
+		return buf
+	}
+	return appendBase62(buf, id[:])
 }
 
 // UnmarshalText unmarshals from hexidicmal encoded text
@@ -254,7 +825,7 @@ func (id *Key) UnmarshalText(text []byte) error {
 		return err
 	}
 	if len(data) != 8 {
-		return errors.New("BUID length must be 128 bit")
+		return errors.New("key length must be 64 bit")
 	}
 	copy(id[:], data)
 	return nil
@@ -266,7 +837,70 @@ func (id Key) String() string {
 	return string(text)
 }
 
-func join(shard Shard, key Key) ID {
+// IsZero returns whether or not the Shard is initialized
+func (s Shard) IsZero() bool { return s == Shard{} }
+
+// MarshalText returns the base-62 encoded text
+func (s Shard) MarshalText() (text []byte, err error) {
+	if s.IsZero() {
+		return nil, nil
+	}
+	return s.AppendText(make([]byte, 0, 11)), nil
+}
+
+// AppendText appends the base-62 encoded text to buf and returns the extended
+// buffer, without allocating if buf has enough capacity
+func (s Shard) AppendText(buf []byte) []byte {
+	if s.IsZero() {
+		return buf
+	}
+	return appendBase62(buf, s[:])
+}
+
+// UnmarshalText unmarshals from base-62 encoded text
+func (s *Shard) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		return nil
+	}
+	data, err := base62Encoding.Decode(string(text))
+	if err != nil {
+		return err
+	}
+	if len(data) != 8 {
+		return errors.New("shard length must be 64 bit")
+	}
+	copy(s[:], data)
+	return nil
+}
+
+// String returns the base-62 encoded string
+func (s Shard) String() string {
+	text, _ := s.MarshalText()
+	return string(text)
+}
+
+// MigrateProcess returns a copy of oldID with the process replaced by
+// newProcessID, e.g. when the process IDs are reassigned in a rolling restart
+//
+// The counter is incremented by 1 to avoid colliding with an ID the old
+// process might have generated in the same nanosecond, so the result sorts
+// after oldID. If the counter is already at its max, it is reset and the time
+// proceeds by 1 nanosecond instead. The shard index and namespace are
+// preserved.
+func MigrateProcess(oldID ID, newProcessID uint16) ID {
+	f := oldID.Fields()
+	if f.Counter < maxCounter {
+		f.Counter++
+	} else {
+		f.setNanos(f.nanos() + 1)
+		f.Counter = 0
+	}
+	f.Process = newProcessID
+	return f.ID()
+}
+
+// Join joins Shard and Key to BUID, the inverse of ID.Split
+func Join(shard Shard, key Key) ID {
 	var id ID
 	copy(id[:8], shard[:])
 	copy(id[8:], key[:])
@@ -275,26 +909,150 @@ func join(shard Shard, key Key) ID {
 
 // Index returns the embedded shard index
 func (s Shard) Index() uint16 {
-	return join(s, Key{}).Shard()
+	return Join(s, Key{}).Shard()
 }
 
 // Time returns the embedded hours in time.Time
 func (s Shard) Time() time.Time {
-	return join(s, Key{}).Time()
+	return Join(s, Key{}).Time()
+}
+
+// TimeWindow returns the UTC hour [start, end) embedded in the shard, e.g. to
+// query the keys of the shard within the hour
+func (s Shard) TimeWindow() (start, end time.Time) {
+	start = s.Time()
+	return start, start.Add(time.Hour)
+}
+
+// ContainsID returns whether the shard part of id is s
+//
+// After sharding a database row by Shard.Index(), use ContainsID to confirm
+// that an incoming ID routes to the correct shard.
+func (s Shard) ContainsID(id ID) bool {
+	return Shard(id[:8]) == s
+}
+
+// KeyRange returns the minimum and maximum keys within the window [from, to],
+// as durations from the start of the hour of a shard, for a range scan of the
+// keys stored in the shard
+//
+// It panics unless 0 <= from <= to < time.Hour.
+func KeyRange(from, to time.Duration) (minKey, maxKey Key) {
+	if from < 0 || to >= time.Hour || from > to {
+		panic(fmt.Sprintf("buid: window [%v, %v] is not within an hour", from, to))
+	}
+	return newKey(from, 0, 0), newKey(to, maxCounter, 0xffff)
+}
+
+// newKey packs a key from the duration within an hour, counter and process
+func newKey(d time.Duration, counter, process uint16) Key {
+	t := int64(d)
+	_, key := IDFields{
+		Minute:     uint8(t / minuteInNano),
+		Second:     uint8((t % minuteInNano) / secondInNano),
+		Nanosecond: uint32(t % secondInNano),
+		Counter:    counter,
+		Process:    process,
+	}.ID().Split()
+	return key
+}
+
+// MinIDForShard returns the smallest ID at the shard index within the hour of
+// t, for use as the inclusive lower bound of a range scan
+//
+// The namespace is zero, which is the default of NewID.
+func MinIDForShard(shardIdx uint16, t time.Time) ID {
+	return Join(newShard(shardIdx, hourOf(t)), Key{})
+}
+
+// AllShards returns the shards of each index in [from, to] within the hour of
+// t, e.g. to schedule table scans covering the shards
+func AllShards(from, to uint16, t time.Time) []Shard {
+	if from > to {
+		return nil
+	}
+	hour := hourOf(t)
+	shards := make([]Shard, 0, int(to)-int(from)+1)
+	for i := int(from); i <= int(to); i++ {
+		shards = append(shards, newShard(uint16(i), hour))
+	}
+	return shards
+}
+
+// AllShardsForHour returns all 65536 shards within the hour, which is counted
+// from Epoch like the embedded hours
+func AllShardsForHour(hour uint32) []Shard {
+	shards := make([]Shard, 0x10000)
+	for i := range shards {
+		shards[i] = newShard(uint16(i), hour)
+	}
+	return shards
+}
+
+// NewShardForTime returns the shard of index within the hour of t, with a
+// zero namespace, e.g. to build the boundary of a range query
+//
+// It panics if t is before Epoch.
+func NewShardForTime(index uint16, t time.Time) Shard {
+	if internalTime(t) < 0 {
+		panic(fmt.Sprintf("buid: time %v is before epoch", t))
+	}
+	return newShard(index, hourOf(t))
+}
+
+// NewKeyForTime returns the key of the duration d within the hour, counter and
+// process, e.g. to build the boundary of a range query
+//
+// It panics if d is not within [0, time.Hour) or counter exceeds 6 bits.
+func NewKeyForTime(d time.Duration, counter, process uint16) Key {
+	if d < 0 || d >= time.Hour {
+		panic(fmt.Sprintf("buid: duration %v is not within an hour", d))
+	}
+	if counter > maxCounter {
+		panic(fmt.Sprintf("buid: counter %d exceeds %d", counter, maxCounter))
+	}
+	return newKey(d, counter, process)
+}
+
+// newShard packs a shard from a shard index and hours from Epoch
+func newShard(index uint16, hour uint32) Shard {
+	return IDFields{ShardIndex: index, Hour: hour}.ID().ShardPart()
+}
+
+// hourOf returns the hours from Epoch of t
+func hourOf(t time.Time) uint32 {
+	return uint32(internalTime(t) / hourInNano)
+}
+
+// MaxIDForShard returns the largest ID at the shard index within the hour of
+// t, for use as the inclusive upper bound of a range scan
+//
+// The namespace is zero, which is the default of NewID.
+func MaxIDForShard(shardIdx uint16, t time.Time) ID {
+	id := MinIDForShard(shardIdx, t)
+	for i := 8; i < len(id); i++ {
+		id[i] = 0xff
+	}
+	return id
 }
 
 // Time returns the embedded time in time.Duration
 func (k Key) Time() time.Duration {
-	t := join(Shard{}, k).Time()
+	t := Join(Shard{}, k).Time()
 	return t.Sub(t.Truncate(time.Hour))
 }
 
 // Process returns the embedded process ID
 func (k Key) Process() uint16 {
-	return join(Shard{}, k).Process()
+	return Join(Shard{}, k).Process()
 }
 
 // Counter returns the embedded counter part of the key
 func (k Key) Counter() uint16 {
-	return join(Shard{}, k).Counter()
+	return Join(Shard{}, k).Counter()
+}
+
+// ContainsID returns whether the key part of id is k
+func (k Key) ContainsID(id ID) bool {
+	return Key(id[8:]) == k
 }
@@ -1,9 +1,13 @@
 package buid
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math"
 	"runtime"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -117,6 +121,29 @@ func TestShardTime(t *testing.T) {
 	}
 }
 
+func TestShardTimeWindow(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 14, 34, 56, 123456789, time.UTC)
+	start, end := newShard(42, hourOf(ts)).TimeWindow()
+	if expected := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC); !start.Equal(expected) {
+		t.Fatalf("expect %v got %v", expected, start)
+	}
+	if start.Location() != time.UTC {
+		t.Fatalf("expect UTC got %v", start.Location())
+	}
+	if !end.Equal(start.Add(time.Hour)) {
+		t.Fatalf("expect %v got %v", start.Add(time.Hour), end)
+	}
+	if ts.Before(start) || !ts.Before(end) {
+		t.Fatalf("expect %v within [%v, %v)", ts, start, end)
+	}
+
+	epoch := time.Unix(0, Epoch).UTC()
+	start, _ = newShard(1, 0).TimeWindow()
+	if expected := epoch.Truncate(time.Hour); !start.Equal(expected) {
+		t.Fatalf("expect %v got %v", expected, start)
+	}
+}
+
 func TestKeyTime(t *testing.T) {
 	process := NewProcess(1)
 	ts := externalTime(process.t)
@@ -277,3 +304,1118 @@ func TestIsZero(t *testing.T) {
 		t.Fatal("expect zero is not true")
 	}
 }
+
+func TestNetworkByteOrder(t *testing.T) {
+	id := NewProcess(0x0102).NewID(0x0304, time.Now())
+	b := id.ToNetworkByteOrder()
+	if b[0] != 0x03 || b[1] != 0x04 || b[14] != 0x01 || b[15] != 0x02 {
+		t.Fatalf("expect big-endian got %x", b)
+	}
+	b[0] = 0xff
+	if id[0] == 0xff {
+		t.Fatal("expect a copy")
+	}
+	if FromNetworkByteOrder(id.ToNetworkByteOrder()) != id {
+		t.Fatal("expect round trip")
+	}
+}
+
+func TestBitAt(t *testing.T) {
+	var id ID
+	id[0] = 0x80
+	id[15] = 0x01
+	if !id.BitAt(0) || id.BitAt(1) || id.BitAt(126) || !id.BitAt(127) {
+		t.Fatalf("unexpected bits of %x", id[:])
+	}
+
+	// the process field occupies the last 16 bits
+	id = NewProcess(0x8001).NewID(1, time.Now())
+	if !id.BitAt(112) || id.BitAt(113) || !id.BitAt(127) {
+		t.Fatalf("unexpected process bits of %x", id[:])
+	}
+}
+
+func TestSetBit(t *testing.T) {
+	var id ID
+	for i := 0; i < 128; i++ {
+		set := id.SetBit(i, true)
+		if !set.BitAt(i) {
+			t.Fatalf("expect bit %d set", i)
+		}
+		if set.SetBit(i, false) != id {
+			t.Fatalf("expect bit %d cleared", i)
+		}
+	}
+	if id != (ID{}) {
+		t.Fatal("expect SetBit not to modify the receiver")
+	}
+	if id.SetBit(127, true).Process() != 1 {
+		t.Fatal("expect process 1")
+	}
+	if id.SetBit(15, true).Shard() != 1 {
+		t.Fatal("expect shard 1")
+	}
+}
+
+func TestBitPositionOutOfRange(t *testing.T) {
+	var id ID
+	for _, position := range []int{-1, -8, 128} {
+		expectPanic(t, func() { id.BitAt(position) })
+		expectPanic(t, func() { id.SetBit(position, true) })
+	}
+}
+
+func TestNewIDAtUnixNano(t *testing.T) {
+	process := NewProcess(1)
+	ts := time.Now().UTC().Add(time.Second)
+	id, err := process.NewIDAtUnixNano(2, ts.UnixNano())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id.Time().Equal(ts) {
+		t.Fatalf("expect %v got %v", ts, id.Time())
+	}
+	if id.Shard() != 2 || id.Process() != 1 {
+		t.Fatalf("unexpected ID %v", id)
+	}
+
+	if _, err := process.NewIDAtUnixNano(2, Epoch-1); err != ErrBeforeEpoch {
+		t.Fatalf("expect %v got %v", ErrBeforeEpoch, err)
+	}
+	future := time.Now().Add(MaxFuture + time.Hour).UnixNano()
+	if _, err := process.NewIDAtUnixNano(2, future); err != ErrFutureTooFar {
+		t.Fatalf("expect %v got %v", ErrFutureTooFar, err)
+	}
+}
+
+func TestWithMaxFuture(t *testing.T) {
+	process := NewProcessWithOptions(WithProcessID(1), WithMaxFuture(time.Hour))
+	if _, err := process.NewIDAtUnixNano(2, time.Now().Add(time.Minute).UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := process.NewIDAtUnixNano(2, time.Now().Add(2*time.Hour).UnixNano()); err != ErrFutureTooFar {
+		t.Fatalf("expect %v got %v", ErrFutureTooFar, err)
+	}
+	// other processes keep the default
+	if _, err := NewProcess(1).NewIDAtUnixNano(2, time.Now().Add(2*time.Hour).UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkNewIDAtUnixNano(b *testing.B) {
+	process := NewProcess(1)
+	t := time.Now().UnixNano()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		process.NewIDAtUnixNano(2, t)
+		t++
+	}
+}
+
+func TestAsKey(t *testing.T) {
+	id := NewProcess(1).NewID(2, time.Now())
+	key := id.AsKey()
+	if *key != [16]byte(id) {
+		t.Fatal("expect the same bytes")
+	}
+	key[0] = 0xff
+	if id[0] != 0xff {
+		t.Fatal("expect the pointer to alias the ID")
+	}
+}
+
+func BenchmarkMapLookupByID(b *testing.B) {
+	p := NewProcess(1)
+	ids := make([]ID, 1000)
+	m := make(map[ID]int, len(ids))
+	for i := range ids {
+		ids[i] = p.NewID(2, time.Now())
+		m[ids[i]] = i
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = m[ids[n%len(ids)]]
+	}
+}
+
+func BenchmarkMapLookupByPointer(b *testing.B) {
+	p := NewProcess(1)
+	ids := make([]ID, 1000)
+	m := make(map[*[16]byte]int, len(ids))
+	for i := range ids {
+		ids[i] = p.NewID(2, time.Now())
+		m[ids[i].AsKey()] = i
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = m[ids[n%len(ids)].AsKey()]
+	}
+}
+
+func TestContainsID(t *testing.T) {
+	p := NewProcess(1)
+	id := p.NewID(42, time.Now())
+	shard, key := id.Split()
+	if !shard.ContainsID(id) || !key.ContainsID(id) {
+		t.Fatal("expect the parts to contain the ID")
+	}
+	if Join(shard, key) != id {
+		t.Fatal("expect join to restore the ID")
+	}
+
+	other := p.NewID(43, time.Now())
+	if shard.ContainsID(other) {
+		t.Fatal("expect a different shard not to contain the ID")
+	}
+	if key.ContainsID(other) {
+		t.Fatal("expect a different key not to contain the ID")
+	}
+}
+
+func TestShardLocalString(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, key := id.Split()
+	s := id.ToShardLocalString()
+	if s != key.String() || len(s) > 11 || len(s) >= len(id.String()) {
+		t.Fatalf("unexpected shard-local string %s", s)
+	}
+	parsed, err := NewIDFromShardLocalString(s, shard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != id {
+		t.Fatalf("expect %v got %v", id, parsed)
+	}
+	if _, err := NewIDFromShardLocalString("", shard); err == nil {
+		t.Fatal("expect error")
+	}
+	if _, err := NewIDFromShardLocalString(id.String(), shard); err == nil {
+		t.Fatal("expect error")
+	}
+
+	// a zero key part
+	for _, id := range []ID{MinIDForShard(1, time.Now()), NewProcess(0).NewID(1, time.Now().Add(time.Hour).Truncate(time.Hour))} {
+		shard, key := id.Split()
+		if !key.IsZero() {
+			t.Fatalf("expect zero key got %v", key)
+		}
+		s := id.ToShardLocalString()
+		if s != "00000000" {
+			t.Fatalf("expect 00000000 got %s", s)
+		}
+		parsed, err := NewIDFromShardLocalString(s, shard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != id {
+			t.Fatalf("expect %v got %v", id, parsed)
+		}
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	if id.Namespace() != 0 {
+		t.Fatalf("expect 0 got %d", id.Namespace())
+	}
+	ns := id.SetNamespace(0xabcd)
+	if ns.Namespace() != 0xabcd {
+		t.Fatalf("expect 0xabcd got %x", ns.Namespace())
+	}
+	if ns.SetNamespace(0x0001).Namespace() != 1 {
+		t.Fatal("expect the previous namespace to be cleared")
+	}
+	if ns.Shard() != id.Shard() || !ns.Time().Equal(id.Time()) || ns.Process() != id.Process() || ns.Counter() != id.Counter() {
+		t.Fatal("expect other fields unchanged")
+	}
+	if id.Namespace() != 0 {
+		t.Fatal("expect SetNamespace not to modify the receiver")
+	}
+}
+
+func TestNewIDWithNamespace(t *testing.T) {
+	process := NewProcess(2)
+	ts := time.Now().UTC().Add(time.Second)
+	id, err := process.NewIDWithNamespace(1, 7, ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Shard() != 1 || id.Namespace() != 7 || !id.Time().Equal(ts) {
+		t.Fatalf("unexpected ID %v", id)
+	}
+	if _, err := process.NewIDWithNamespace(1, 7, externalTime(-1)); err != ErrBeforeEpoch {
+		t.Fatalf("expect %v got %v", ErrBeforeEpoch, err)
+	}
+}
+
+func TestEnsureMonotonicity(t *testing.T) {
+	process := NewProcess(1)
+	if err := process.EnsureMonotonicity(); err != nil {
+		t.Fatal(err)
+	}
+	process.NewID(2, time.Now().Add(30*time.Minute))
+	if err := process.EnsureMonotonicity(); err != nil {
+		t.Fatal(err)
+	}
+	process.NewID(2, time.Now().Add(2*time.Hour))
+	if err := process.EnsureMonotonicity(); err == nil {
+		t.Fatal("expect error for a future state")
+	}
+	process.t = internalTime(time.Now().Add(-25 * time.Hour))
+	if err := process.EnsureMonotonicity(); err == nil {
+		t.Fatal("expect error for a stale state")
+	}
+}
+
+func TestParseID(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	parsed, err := ParseID(id.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != id {
+		t.Fatalf("expect %v got %v", id, parsed)
+	}
+	for _, s := range []string{"", "!", "ff"} {
+		if _, err := ParseID(s); err == nil {
+			t.Fatalf("expect error for %q", s)
+		}
+	}
+}
+
+func TestNewIDs(t *testing.T) {
+	process := NewProcess(12)
+	if ids := process.NewIDs(1, time.Now(), 0); ids != nil {
+		t.Fatalf("expect nil got %v", ids)
+	}
+	if ids := process.NewIDs(1, time.Now(), -1); ids != nil {
+		t.Fatalf("expect nil got %v", ids)
+	}
+
+	// a batch larger than the counter range overflows mid-batch
+	ids := process.NewIDs(1, time.Now(), 3*(maxCounter+1))
+	type tc struct {
+		t       time.Time
+		counter uint16
+	}
+	seen := make(map[tc]bool)
+	for i, id := range ids {
+		if id.Shard() != 1 || id.Process() != 12 {
+			t.Fatalf("expect shard 1 and process 12 got %d and %d", id.Shard(), id.Process())
+		}
+		k := tc{id.Time(), id.Counter()}
+		if seen[k] {
+			t.Fatalf("duplicated (t, counter) pair %v", k)
+		}
+		seen[k] = true
+		if i > 0 && id.Time().Before(ids[i-1].Time()) {
+			t.Fatalf("expect %v not before %v", id.Time(), ids[i-1].Time())
+		}
+	}
+}
+
+func TestNewIDsUniqueness(t *testing.T) {
+	process := NewProcess(12)
+	var wg sync.WaitGroup
+	n := runtime.NumCPU()
+	idss := make([][]ID, n)
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				idss[i] = append(idss[i], process.NewIDs(1, time.Now(), 100)...)
+			}
+		}()
+	}
+	wg.Wait()
+	m := make(map[ID]bool)
+	for _, ids := range idss {
+		for _, id := range ids {
+			if m[id] {
+				t.Fatal("duplication detected")
+			}
+			m[id] = true
+		}
+	}
+}
+
+func BenchmarkNewIDs(b *testing.B) {
+	process := NewProcess(1)
+	t := time.Now()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		ids := process.NewIDs(2, t, 64)
+		_ = ids
+		t = t.Add(time.Nanosecond)
+	}
+}
+
+func TestMinMaxIDForShard(t *testing.T) {
+	p := NewProcess(0xffff)
+	hour := externalTime(p.t).Add(time.Hour).Truncate(time.Hour)
+	min, max := MinIDForShard(3, hour.Add(30*time.Minute)), MaxIDForShard(3, hour)
+	for _, id := range []ID{min, max} {
+		if id.Shard() != 3 {
+			t.Fatalf("expect shard 3 got %d", id.Shard())
+		}
+		if shard, _ := id.Split(); !shard.Time().Equal(hour) {
+			t.Fatalf("expect %v got %v", hour, shard.Time())
+		}
+	}
+	for _, ts := range []time.Time{hour, hour.Add(time.Nanosecond), hour.Add(time.Hour - time.Nanosecond)} {
+		for i := 0; i <= maxCounter; i++ {
+			id := p.NewID(3, ts)
+			if id.Compare(min) < 0 || id.Compare(max) > 0 {
+				t.Fatalf("expect %v between %v and %v", id, min, max)
+			}
+		}
+	}
+	q := NewProcess(0xffff)
+	for _, id := range []ID{
+		q.NewID(2, hour.Add(30*time.Minute)),
+		q.NewID(4, hour.Add(30*time.Minute)),
+		q.NewID(3, hour.Add(time.Hour)),
+	} {
+		if id.Compare(min) >= 0 && id.Compare(max) <= 0 {
+			t.Fatalf("expect %v outside %v and %v", id, min, max)
+		}
+	}
+}
+
+func TestEqualsShardAndKey(t *testing.T) {
+	p := NewProcess(2)
+	id := p.NewID(1, time.Now())
+	shard, key := id.Split()
+	if !id.EqualsShard(shard) || !id.EqualsKey(key) {
+		t.Fatal("expect equal")
+	}
+	other := p.NewID(2, time.Now())
+	otherShard, otherKey := other.Split()
+	if id.EqualsShard(otherShard) || id.EqualsKey(otherKey) {
+		t.Fatal("expect not equal")
+	}
+}
+
+func BenchmarkEqualsShard(b *testing.B) {
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, _ := id.Split()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if !id.EqualsShard(shard) {
+			b.Fatal("expect equal")
+		}
+	}
+}
+
+func BenchmarkSplitShard(b *testing.B) {
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, _ := id.Split()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if s, _ := id.Split(); s != shard {
+			b.Fatal("expect equal")
+		}
+	}
+}
+
+func BenchmarkEqualsKey(b *testing.B) {
+	id := NewProcess(2).NewID(1, time.Now())
+	_, key := id.Split()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if !id.EqualsKey(key) {
+			b.Fatal("expect equal")
+		}
+	}
+}
+
+func BenchmarkSplitKey(b *testing.B) {
+	id := NewProcess(2).NewID(1, time.Now())
+	_, key := id.Split()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, k := id.Split(); k != key {
+			b.Fatal("expect equal")
+		}
+	}
+}
+
+func TestProcessIDFromHostPID(t *testing.T) {
+	if id := processIDFromHostPID("host-a", 1234); id != 0xc698 {
+		t.Fatalf("expect %x got %x", 0xc698, id)
+	}
+	if processIDFromHostPID("host-a", 1234) != processIDFromHostPID("host-a", 1234) {
+		t.Fatal("expect deterministic process ID")
+	}
+	if processIDFromHostPID("host-a", 1234) == processIDFromHostPID("host-b", 1234) {
+		t.Fatal("expect different process IDs for different hosts")
+	}
+}
+
+func TestNewProcessFromHostPID(t *testing.T) {
+	p1, err := NewProcessFromHostPID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := NewProcessFromHostPID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p1.id != p2.id {
+		t.Fatalf("expect %d got %d", p1.id, p2.id)
+	}
+	if p1.id == 0 {
+		t.Fatal("expect non-zero process ID")
+	}
+}
+
+func TestAge(t *testing.T) {
+	p := NewProcess(2)
+	ts := time.Now().Add(-time.Second)
+	p.t = internalTime(ts)
+	id := p.NewID(1, ts)
+	if age := id.Age(); age < time.Second {
+		t.Fatalf("expect age >= 1s got %v", age)
+	}
+	if !id.OlderThan(0) || !id.OlderThan(time.Second/2) {
+		t.Fatal("expect older")
+	}
+	if id.OlderThan(time.Hour) {
+		t.Fatal("expect not older than an hour")
+	}
+	if future := p.NewID(1, time.Now().Add(time.Hour)); future.OlderThan(0) {
+		t.Fatal("expect an ID from the future not older than 0")
+	}
+}
+
+func TestTimeIn(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	id := NewProcess(2).NewID(1, ts)
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	if tm := id.TimeIn(loc); !tm.Equal(ts) || tm.Location() != loc {
+		t.Fatalf("expect %v got %v", ts.In(loc), tm)
+	}
+	if tm := id.LocalTime(); !tm.Equal(ts) || tm.Location() != time.Local {
+		t.Fatalf("expect %v got %v", ts.Local(), tm)
+	}
+	if s, expected := id.FormatTime(time.RFC3339, loc), "2100-01-02T11:04:05+08:00"; s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+}
+
+func TestNewIDInRange(t *testing.T) {
+	p := NewProcess(2)
+	now := time.Now()
+	id, err := p.NewIDInRange(1, now.Add(-time.Minute), now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Shard() != 1 || id.Time().Before(now) || id.Time().After(now.Add(time.Minute)) {
+		t.Fatalf("unexpected ID %v", id.Verbose())
+	}
+	for _, r := range [][2]time.Time{
+		{now.Add(time.Minute), now.Add(time.Hour)},
+		{now.Add(-time.Hour), now.Add(-time.Minute)},
+		{now.Add(time.Minute), now.Add(-time.Minute)},
+	} {
+		if _, err := p.NewIDInRange(1, r[0], r[1]); err != ErrOutOfRange {
+			t.Fatalf("expect %v got %v", ErrOutOfRange, err)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	p := NewProcess(0xabcd)
+	base := externalTime(p.t).Add(time.Hour).Truncate(time.Hour)
+	for _, ts := range []time.Time{
+		base,
+		base.Add(time.Nanosecond),
+		base.Add(59*time.Minute + 59*time.Second + 999999999),
+		base.Add(1234 * time.Hour),
+	} {
+		for _, shard := range []uint16{0, 1, 0xffff} {
+			id := p.NewID(shard, ts)
+			f := id.Fields()
+			if f.ShardIndex != id.Shard() || f.Process != id.Process() || f.Counter != id.Counter() || f.Namespace != 0 {
+				t.Fatalf("unexpected fields %+v of %v", f, id.Verbose())
+			}
+			if f.Minute != uint8(ts.Minute()) || f.Second != uint8(ts.Second()) || f.Nanosecond != uint32(ts.Nanosecond()) {
+				t.Fatalf("unexpected fields %+v of %v", f, ts)
+			}
+			if id2 := f.ID(); id2 != id {
+				t.Fatalf("expect %v got %v", id, id2)
+			}
+			id = id.SetNamespace(0x1234)
+			if id2 := id.Fields().ID(); id2 != id {
+				t.Fatalf("expect %v got %v", id, id2)
+			}
+		}
+	}
+}
+
+func BenchmarkFields(b *testing.B) {
+	id := NewProcess(1).NewID(2, time.Now())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = id.Fields()
+	}
+}
+
+func TestNewIDNow(t *testing.T) {
+	process := NewProcess(12)
+	before := time.Now()
+	id := process.NewIDNow(1)
+	after := time.Now()
+	if id.Shard() != 1 || id.Process() != 12 {
+		t.Fatalf("unexpected ID %v", id.Verbose())
+	}
+	if id.Time().Before(before) || id.Time().After(after.Add(time.Millisecond)) {
+		t.Fatalf("expect %v within [%v, %v]", id.Time(), before, after)
+	}
+
+	var wg sync.WaitGroup
+	n := runtime.NumCPU()
+	idss := make([][]ID, n)
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]ID, 10000)
+			for j := range ids {
+				ids[j] = process.NewIDNow(1)
+			}
+			idss[i] = ids
+		}()
+	}
+	wg.Wait()
+	m := make(map[ID]bool)
+	for _, ids := range idss {
+		for _, id := range ids {
+			if m[id] {
+				t.Fatal("duplication detected")
+			}
+			m[id] = true
+		}
+	}
+}
+
+func TestNewProcessWithOptions(t *testing.T) {
+	p := NewProcessWithOptions()
+	if p.id != 0 || p.counter != 0 {
+		t.Fatalf("unexpected process %d %d", p.id, p.counter)
+	}
+
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	p = NewProcessWithOptions(
+		WithProcessID(12),
+		WithInitialTime(ts),
+		WithInitialCounter(5),
+		WithClock(func() time.Time { return ts }),
+	)
+	id := p.NewIDNow(1)
+	if id.Process() != 12 || id.Counter() != 5 || !id.Time().Equal(ts) {
+		t.Fatalf("unexpected ID %v", id.Verbose())
+	}
+
+	p = NewProcessWithOptions(WithClock(func() time.Time { return ts }))
+	if !externalTime(p.t).Equal(ts.Add(time.Nanosecond)) {
+		t.Fatalf("expect %v got %v", ts.Add(time.Nanosecond), externalTime(p.t))
+	}
+}
+
+func TestCounterOverflowWithClock(t *testing.T) {
+	now := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	clock := func() time.Time {
+		now = now.Add(time.Nanosecond)
+		return now
+	}
+	process := NewProcessWithOptions(WithProcessID(1), WithClock(clock))
+	ts := externalTime(process.t)
+	for i := 0; i <= maxCounter; i++ {
+		if id := process.NewID(2, ts); int(id.Counter()) != i || !id.Time().Equal(ts) {
+			t.Fatalf("unexpected ID %v", id.Verbose())
+		}
+	}
+	// the overflowed counter waits for the clock instead of the wall time
+	id := process.NewID(2, ts)
+	if id.Counter() != 0 || !id.Time().After(ts) || id.Time().After(now) {
+		t.Fatalf("unexpected ID %v", id.Verbose())
+	}
+}
+
+func TestKeyRange(t *testing.T) {
+	p := NewProcess(0xffff)
+	hour := externalTime(p.t).Add(time.Hour).Truncate(time.Hour)
+	from, to := 10*time.Minute, 20*time.Minute+30*time.Second
+	minKey, maxKey := KeyRange(from, to)
+	if minKey.Time() != from || maxKey.Time() != to {
+		t.Fatalf("expect [%v, %v] got [%v, %v]", from, to, minKey.Time(), maxKey.Time())
+	}
+	if minKey.Counter() != 0 || minKey.Process() != 0 || maxKey.Counter() != maxCounter || maxKey.Process() != 0xffff {
+		t.Fatalf("unexpected keys %x %x", minKey, maxKey)
+	}
+	less := func(a, b Key) bool { return string(a[:]) < string(b[:]) }
+	for _, d := range []time.Duration{from, from + time.Nanosecond, 15 * time.Minute, to - time.Nanosecond, to} {
+		for i := 0; i <= maxCounter; i++ {
+			_, key := p.NewID(3, hour.Add(d)).Split()
+			if less(key, minKey) || less(maxKey, key) {
+				t.Fatalf("expect %x between %x and %x", key, minKey, maxKey)
+			}
+		}
+	}
+	q := NewProcess(0)
+	for _, d := range []time.Duration{from - time.Nanosecond, to + time.Nanosecond} {
+		_, key := q.NewID(3, hour.Add(d)).Split()
+		if !less(key, minKey) && !less(maxKey, key) {
+			t.Fatalf("expect %x outside %x and %x", key, minKey, maxKey)
+		}
+	}
+}
+
+func TestKeyRangeEdges(t *testing.T) {
+	last := time.Hour - time.Nanosecond
+	minKey, maxKey := KeyRange(0, last)
+	if minKey.Time() != 0 || maxKey.Time() != last {
+		t.Fatalf("expect [0, %v] got [%v, %v]", last, minKey.Time(), maxKey.Time())
+	}
+	for _, w := range [][2]time.Duration{
+		{0, time.Hour},
+		{-time.Nanosecond, time.Minute},
+		{-time.Minute, -time.Nanosecond},
+		{2 * time.Minute, time.Minute},
+	} {
+		expectPanic(t, func() { KeyRange(w[0], w[1]) })
+	}
+}
+
+func TestMask(t *testing.T) {
+	id, err := NewProcess(0xabcd).NewIDWithNamespace(0x1234, 7, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := id
+	for _, masked := range []ID{id.MaskShard(), id.MaskProcess()} {
+		if !masked.Time().Equal(id.Time()) || masked.Counter() != id.Counter() || masked.Namespace() != 7 {
+			t.Fatalf("expect time, counter and namespace unchanged got %v", masked.Verbose())
+		}
+	}
+	if m := id.MaskShard(); m.Shard() != 0 || m.Process() != 0xabcd {
+		t.Fatalf("unexpected masked ID %v", m.Verbose())
+	}
+	if m := id.MaskProcess(); m.Process() != 0 || m.Shard() != 0x1234 {
+		t.Fatalf("unexpected masked ID %v", m.Verbose())
+	}
+	if id != orig {
+		t.Fatal("expect the ID unmodified")
+	}
+}
+
+func TestWithShardAndProcess(t *testing.T) {
+	id, err := NewProcess(0xabcd).NewIDWithNamespace(0x1234, 7, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := id
+	f := id.Fields()
+
+	withShard := id.WithShard(0xfedc)
+	expected := f
+	expected.ShardIndex = 0xfedc
+	if actual := withShard.Fields(); actual != expected {
+		t.Fatalf("expect %+v got %+v", expected, actual)
+	}
+	if withShard.Shard() != 0xfedc {
+		t.Fatalf("expect %d got %d", 0xfedc, withShard.Shard())
+	}
+
+	withProcess := id.WithProcess(0x0102)
+	expected = f
+	expected.Process = 0x0102
+	if actual := withProcess.Fields(); actual != expected {
+		t.Fatalf("expect %+v got %+v", expected, actual)
+	}
+	if withProcess.Process() != 0x0102 {
+		t.Fatalf("expect %d got %d", 0x0102, withProcess.Process())
+	}
+
+	if id != orig {
+		t.Fatal("expect the ID unmodified")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	var max ID
+	for i := range max {
+		max[i] = 0xff
+	}
+	ids := []ID{{}, max}
+	nearEpoch := NewProcessWithOptions(WithProcessID(0xffff), WithInitialTime(externalTime(0)))
+	for i := 0; i <= maxCounter; i++ {
+		ids = append(ids, nearEpoch.NewID(0xffff, externalTime(0)))
+	}
+	p := NewProcess(0xffff)
+	for _, shard := range []uint16{0, 1, 0x8000, 0xffff} {
+		ids = append(ids, p.NewIDNow(shard), p.NewID(shard, time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)))
+	}
+	for _, id := range ids {
+		if id2 := Join(id.Split()); id2 != id {
+			t.Fatalf("expect %v got %v", id, id2)
+		}
+	}
+	if id := ids[2+maxCounter]; id.Counter() != maxCounter || !id.Time().Equal(externalTime(0)) {
+		t.Fatalf("expect max counter at epoch got %v", id.Verbose())
+	}
+}
+
+func TestTimeResidual(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	id := NewProcess(1).NewID(2, ts)
+	shard, key := id.Split()
+	if d, expected := id.TimeResidual(), 4*time.Minute+5*time.Second+6; d != expected || d != key.Time() {
+		t.Fatalf("expect %v got %v", expected, d)
+	}
+	if h, expected := id.TimeHour(), ts.Truncate(time.Hour); !h.Equal(expected) || !h.Equal(shard.Time()) {
+		t.Fatalf("expect %v got %v", expected, h)
+	}
+	if !id.TimeHour().Add(id.TimeResidual()).Equal(id.Time()) {
+		t.Fatal("expect the hour and the residual to add up to the time")
+	}
+}
+
+func BenchmarkTimeResidual(b *testing.B) {
+	id := NewProcess(1).NewID(2, time.Now())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = id.TimeResidual()
+	}
+}
+
+func TestShardPartAndKey(t *testing.T) {
+	p := NewProcess(0xabcd)
+	for _, shard := range []uint16{0, 1, 0xffff} {
+		id := p.NewIDNow(shard)
+		s, k := id.Split()
+		if id.ShardPart() != s {
+			t.Fatalf("expect %x got %x", s, id.ShardPart())
+		}
+		if id.Key() != k {
+			t.Fatalf("expect %x got %x", k, id.Key())
+		}
+	}
+}
+
+func TestNewIDSlice(t *testing.T) {
+	process := NewProcess(12)
+	if ids := process.NewIDSlice(1, nil); ids != nil {
+		t.Fatalf("expect nil got %v", ids)
+	}
+	base := externalTime(process.t).Add(time.Second)
+	times := []time.Time{
+		base.Add(3 * time.Millisecond),
+		base,
+		base.Add(time.Millisecond),
+		base,
+		base.Add(2 * time.Millisecond),
+	}
+	orig := append([]time.Time(nil), times...)
+	ids := process.NewIDSlice(1, times)
+	if len(ids) != len(times) {
+		t.Fatalf("expect %d IDs got %d", len(times), len(ids))
+	}
+	for i := range times {
+		if !times[i].Equal(orig[i]) {
+			t.Fatal("expect the input unmodified")
+		}
+	}
+	expected := []time.Time{base, base, base.Add(time.Millisecond), base.Add(2 * time.Millisecond), base.Add(3 * time.Millisecond)}
+	for i, id := range ids {
+		if !id.Time().Equal(expected[i]) {
+			t.Fatalf("expect %v got %v", expected[i], id.Time())
+		}
+		if i > 0 && !ids[i-1].Before(id) {
+			t.Fatalf("expect %v before %v", ids[i-1].Verbose(), id.Verbose())
+		}
+	}
+	if ids[0].Counter() != 0 || ids[1].Counter() != 1 {
+		t.Fatalf("expect counters 0 and 1 got %d and %d", ids[0].Counter(), ids[1].Counter())
+	}
+}
+
+func TestSpreadAcrossShards(t *testing.T) {
+	id, _ := NewProcess(12).NewIDWithNamespace(0x1234, 5, time.Now())
+	if ids := id.SpreadAcrossShards(0); len(ids) != 0 {
+		t.Fatalf("expect no IDs got %v", ids)
+	}
+	ids := id.SpreadAcrossShards(300)
+	if len(ids) != 300 {
+		t.Fatalf("expect 300 IDs got %d", len(ids))
+	}
+	shards := make(map[Shard]bool)
+	for i, spread := range ids {
+		if spread.Shard() != uint16(i) {
+			t.Fatalf("expect shard %d got %d", i, spread.Shard())
+		}
+		shard, key := spread.Split()
+		if key != id.Key() {
+			t.Fatalf("expect %x got %x", id.Key(), key)
+		}
+		if spread.MaskShard() != id.MaskShard() {
+			t.Fatalf("expect only the shard index to differ, got %v", spread.Verbose())
+		}
+		shards[shard] = true
+	}
+	if len(shards) != len(ids) {
+		t.Fatalf("expect %d distinct shards got %d", len(ids), len(shards))
+	}
+	if max := id.SpreadAcrossShards(0xffff); max[0xfffe].Shard() != 0xfffe {
+		t.Fatalf("expect shard %d got %d", 0xfffe, max[0xfffe].Shard())
+	}
+}
+
+func TestAllShards(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	hour := ts.Truncate(time.Hour)
+	shards := AllShards(3, 7, ts)
+	if len(shards) != 5 {
+		t.Fatalf("expect 5 shards got %d", len(shards))
+	}
+	for i, shard := range shards {
+		if shard.Index() != uint16(3+i) || !shard.Time().Equal(hour) {
+			t.Fatalf("unexpected shard %d %v", shard.Index(), shard.Time())
+		}
+		if !shard.ContainsID(NewProcess(1).NewID(uint16(3+i), ts)) {
+			t.Fatalf("expect shard %d to contain a generated ID", shard.Index())
+		}
+	}
+	if shards := AllShards(0xfffe, 0xffff, ts); len(shards) != 2 || shards[1].Index() != 0xffff {
+		t.Fatalf("unexpected shards %v", shards)
+	}
+	if shards := AllShards(7, 3, ts); len(shards) != 0 {
+		t.Fatalf("expect no shards got %v", shards)
+	}
+}
+
+func TestAllShardsForHour(t *testing.T) {
+	hour := time.Date(2100, 1, 2, 3, 0, 0, 0, time.UTC)
+	shards := AllShardsForHour(hourOf(hour))
+	if len(shards) != 0x10000 {
+		t.Fatalf("expect 65536 shards got %d", len(shards))
+	}
+	for i, shard := range shards {
+		if shard.Index() != uint16(i) || !shard.Time().Equal(hour) {
+			t.Fatalf("unexpected shard %d %v", shard.Index(), shard.Time())
+		}
+	}
+}
+
+func TestVerifyAging(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	id := NewProcess(1).NewID(2, ts)
+	if err := id.VerifyAgingAt(ts.Add(time.Hour), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := id.VerifyAgingAt(ts.Add(-time.Hour), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	err := id.VerifyAgingAt(ts.Add(time.Hour+time.Nanosecond), time.Hour)
+	if !errors.Is(err, ErrExpiredID) {
+		t.Fatalf("expect %v got %v", ErrExpiredID, err)
+	}
+	for _, s := range []string{ts.String(), ts.Add(time.Hour).String()} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expect %s in %v", s, err)
+		}
+	}
+	if err := id.VerifyAging(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewProcess(1).NewIDNow(2).VerifyAging(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	old := IDFields{Hour: 1}.ID()
+	if err := old.VerifyAging(time.Hour); !errors.Is(err, ErrExpiredID) {
+		t.Fatalf("expect %v got %v", ErrExpiredID, err)
+	}
+}
+
+func TestMigrateProcess(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 59, 59, 999999999, time.UTC)
+	p := NewProcess(1)
+	for _, id := range p.NewIDs(2, ts, maxCounter+1) {
+		id[2], id[3] = 0x12, 0x34 // namespace
+		migrated := MigrateProcess(id, 0xffee)
+		if bytes.Compare(migrated[:], id[:]) <= 0 {
+			t.Fatalf("expect %v after %v", migrated.Verbose(), id.Verbose())
+		}
+		if migrated.Process() != 0xffee {
+			t.Fatalf("expect %d got %d", 0xffee, migrated.Process())
+		}
+		if migrated.Shard() != id.Shard() || migrated.Namespace() != id.Namespace() {
+			t.Fatalf("expect shard %d/%d got %d/%d", id.Shard(), id.Namespace(), migrated.Shard(), migrated.Namespace())
+		}
+		if id.Counter() < maxCounter {
+			if !migrated.Time().Equal(id.Time()) {
+				t.Fatalf("expect %v got %v", id.Time(), migrated.Time())
+			}
+			if migrated.Counter() != id.Counter()+1 {
+				t.Fatalf("expect %d got %d", id.Counter()+1, migrated.Counter())
+			}
+		} else {
+			// carried over the hour
+			if expected := ts.Add(time.Nanosecond); !migrated.Time().Equal(expected) {
+				t.Fatalf("expect %v got %v", expected, migrated.Time())
+			}
+			if migrated.Counter() != 0 {
+				t.Fatalf("expect 0 got %d", migrated.Counter())
+			}
+		}
+	}
+}
+
+func TestNewShardForTime(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 14, 34, 56, 123456789, time.UTC)
+	id := IDFields{ShardIndex: 0xffff, Hour: hourOf(ts)}.ID()
+	if shard := NewShardForTime(0xffff, ts); shard != id.ShardPart() {
+		t.Fatalf("expect %v got %v", id.ShardPart(), shard)
+	}
+	epoch := time.Unix(0, Epoch)
+	if shard := NewShardForTime(1, epoch); !shard.Time().Equal(epoch) || shard.Index() != 1 {
+		t.Fatalf("expect %v got %v", epoch, shard.Time())
+	}
+	expectPanic(t, func() { NewShardForTime(1, epoch.Add(-time.Nanosecond)) })
+}
+
+func TestNewKeyForTime(t *testing.T) {
+	d := time.Hour - time.Nanosecond
+	key := NewKeyForTime(d, maxCounter, 0xffff)
+	if expected := (Key{0xef, 0xbe, 0xe6, 0xb2, 0x7f, 0xff, 0xff, 0xff}); key != expected {
+		t.Fatalf("expect %x got %x", expected[:], key[:])
+	}
+	id := Join(Shard{}, key)
+	if id.TimeResidual() != d || id.Counter() != maxCounter || id.Process() != 0xffff {
+		t.Fatalf("expect %v/%d/%d got %v", d, maxCounter, 0xffff, id.Verbose())
+	}
+	if key := NewKeyForTime(0, 0, 0); key != (Key{}) {
+		t.Fatalf("expect zero got %v", key)
+	}
+	expectPanic(t, func() { NewKeyForTime(time.Hour, 0, 0) })
+	expectPanic(t, func() { NewKeyForTime(-time.Nanosecond, 0, 0) })
+	expectPanic(t, func() { NewKeyForTime(0, maxCounter+1, 0) })
+}
+
+func expectPanic(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expect panic")
+		}
+	}()
+	f()
+}
+
+func TestDistance(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 14, 59, 59, 500000000, time.UTC)
+	p := NewProcessWithOptions(WithProcessID(1), WithInitialTime(ts))
+	a := p.NewID(1, ts)
+	b := p.NewID(2, ts.Add(time.Second))
+	if d := a.Distance(b); d != time.Second {
+		t.Fatalf("expect %v got %v", time.Second, d)
+	}
+	if a.Distance(b) != b.Distance(a) {
+		t.Fatalf("expect %v got %v", a.Distance(b), b.Distance(a))
+	}
+	if d := (ID{}).Distance(ID{}); d != 0 {
+		t.Fatalf("expect 0 got %v", d)
+	}
+	if d := a.Distance(a); d != 0 {
+		t.Fatalf("expect 0 got %v", d)
+	}
+	maxID := IDFields{Hour: math.MaxUint32}.ID()
+	if d := maxID.Distance(ID{}); d != math.MaxInt64 {
+		t.Fatalf("expect %v got %v", time.Duration(math.MaxInt64), d)
+	}
+	if d := (ID{}).Distance(maxID); d != math.MaxInt64 {
+		t.Fatalf("expect %v got %v", time.Duration(math.MaxInt64), d)
+	}
+}
+
+func TestContentionCount(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	var tick time.Duration
+	p := NewProcessWithOptions(
+		WithInitialTime(ts),
+		WithClock(func() time.Time {
+			tick++
+			return ts.Add(tick)
+		}),
+	)
+	p.NewIDs(1, ts, maxCounter+1)
+	if n := p.ContentionCount(); n != 0 {
+		t.Fatalf("expect 0 got %d", n)
+	}
+	p.NewID(1, ts)
+	if n := p.ContentionCount(); n != 1 {
+		t.Fatalf("expect 1 got %d", n)
+	}
+	p.ResetContentionCount()
+	if n := p.ContentionCount(); n != 0 {
+		t.Fatalf("expect 0 got %d", n)
+	}
+}
+
+func BenchmarkContentionCount(b *testing.B) {
+	p := NewProcess(1)
+	// it would deadlock if ContentionCount took the mutex
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.ContentionCount()
+	}
+}
+
+func TestNewIDNoWait(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	p := NewProcessWithOptions(WithProcessID(1), WithInitialTime(ts))
+	for i := 0; i <= maxCounter; i++ {
+		id, ok := p.NewIDNoWait(2, ts)
+		if !ok {
+			t.Fatalf("expect ok at counter %d", i)
+		}
+		if id.Counter() != uint16(i) || !id.Time().Equal(ts) {
+			t.Fatalf("expect %v/%d got %v", ts, i, id.Verbose())
+		}
+	}
+	id, ok := p.NewIDNoWait(2, ts)
+	if ok || !id.IsZero() {
+		t.Fatalf("expect zero and false got %v and %v", id, ok)
+	}
+	if n := p.ContentionCount(); n != 0 {
+		t.Fatalf("expect 0 got %d", n)
+	}
+
+	next := ts.Add(time.Nanosecond)
+	id, ok = p.NewIDNoWait(2, next)
+	if !ok {
+		t.Fatal("expect ok after the time proceeds")
+	}
+	if id.Counter() != 0 || !id.Time().Equal(next) {
+		t.Fatalf("expect %v/0 got %v", next, id.Verbose())
+	}
+}
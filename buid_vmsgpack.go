@@ -0,0 +1,71 @@
+package buid
+
+import (
+	"errors"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MessagePack extension types registered with github.com/vmihailenco/msgpack/v5
+//
+// The payload of each extension is the raw big-endian bytes, so an ID is
+// encoded as fixext 16 (0xd8 0x01 <16 bytes>), a Shard as fixext 8
+// (0xd7 0x02 <8 bytes>) and a Key as fixext 8 (0xd7 0x03 <8 bytes>). A decoder
+// in another language only needs to register these extension types to read
+// BUIDs from msgpack encoded Go structs.
+//
+// The extensions are registered on the pointer types, so a standalone value
+// must be passed to msgpack.Marshal by pointer.
+const (
+	MsgpackExtID    = 1
+	MsgpackExtShard = 2
+	MsgpackExtKey   = 3
+)
+
+func init() {
+	msgpack.RegisterExt(MsgpackExtID, (*ID)(nil))
+	msgpack.RegisterExt(MsgpackExtShard, (*Shard)(nil))
+	msgpack.RegisterExt(MsgpackExtKey, (*Key)(nil))
+}
+
+// MarshalMsgpack implements msgpack.Marshaler
+func (id ID) MarshalMsgpack() ([]byte, error) {
+	return id[:], nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler
+func (id *ID) UnmarshalMsgpack(b []byte) error {
+	if len(b) != len(id) {
+		return errors.New("BUID length must be 128 bit")
+	}
+	copy(id[:], b)
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler
+func (s Shard) MarshalMsgpack() ([]byte, error) {
+	return s[:], nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler
+func (s *Shard) UnmarshalMsgpack(b []byte) error {
+	if len(b) != len(s) {
+		return errors.New("shard length must be 64 bit")
+	}
+	copy(s[:], b)
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler
+func (k Key) MarshalMsgpack() ([]byte, error) {
+	return k[:], nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler
+func (k *Key) UnmarshalMsgpack(b []byte) error {
+	if len(b) != len(k) {
+		return errors.New("key length must be 64 bit")
+	}
+	copy(k[:], b)
+	return nil
+}
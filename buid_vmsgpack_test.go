@@ -0,0 +1,67 @@
+package buid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestVMsgpackRoundTrip(t *testing.T) {
+	type message struct {
+		ID    ID
+		Shard Shard
+		Key   Key
+	}
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, key := id.Split()
+	m1 := message{ID: id, Shard: shard, Key: key}
+	buf, err := msgpack.Marshal(&m1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m2 message
+	if err := msgpack.Unmarshal(buf, &m2); err != nil {
+		t.Fatal(err)
+	}
+	if m1 != m2 {
+		t.Fatalf("expect %v got %v", m1, m2)
+	}
+}
+
+func TestVMsgpackExtFormat(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, key := id.Split()
+	for _, tc := range []struct {
+		v        interface{}
+		expected []byte
+	}{
+		{&id, append([]byte{0xd8, MsgpackExtID}, id[:]...)},
+		{&shard, append([]byte{0xd7, MsgpackExtShard}, shard[:]...)},
+		{&key, append([]byte{0xd7, MsgpackExtKey}, key[:]...)},
+	} {
+		buf, err := msgpack.Marshal(tc.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf, tc.expected) {
+			t.Fatalf("expect %x got %x", tc.expected, buf)
+		}
+	}
+}
+
+func TestVMsgpackLengthError(t *testing.T) {
+	var id ID
+	if err := id.UnmarshalMsgpack(make([]byte, 8)); err == nil {
+		t.Fatal("expect error")
+	}
+	var key Key
+	if err := key.UnmarshalMsgpack(make([]byte, 16)); err == nil {
+		t.Fatal("expect error")
+	}
+	var shard Shard
+	if err := shard.UnmarshalMsgpack(nil); err == nil {
+		t.Fatal("expect error")
+	}
+}
@@ -0,0 +1,50 @@
+package buid
+
+// gregorianOffset is the number of 100-nanosecond intervals between the
+// Gregorian epoch (1582-10-15) of UUID v1 and the Unix epoch
+const gregorianOffset = 0x01b21dd213814000
+
+// MarshalCassandraUUID returns the raw bytes for a Cassandra uuid column, which
+// is a 16-byte big-endian array like the BUID
+func (id ID) MarshalCassandraUUID() [16]byte {
+	return [16]byte(id)
+}
+
+// UnmarshalCassandraUUID returns the ID stored in a Cassandra uuid column by
+// MarshalCassandraUUID
+func UnmarshalCassandraUUID(b [16]byte) ID {
+	return ID(b)
+}
+
+// ToTimeUUID re-encodes the ID as an RFC 4122 version 1 UUID for a Cassandra
+// timeuuid column
+//
+// The BUID fields are mapped to the UUID v1 fields as follows:
+//
+//	| UUID v1 field | BUID field                                                |
+//	|---------------|-----------------------------------------------------------|
+//	| timestamp     | time in 100-nanosecond intervals since 1582-10-15         |
+//	| version       | 1                                                         |
+//	| variant       | RFC 4122                                                  |
+//	| clock_seq     | nanoseconds modulo 100 (7 bits) and counter (6 bits)      |
+//	| node          | shard-hash, namespace and process (2 bytes each)          |
+//
+// No bits of the BUID are lost, but the result sorts by time rather than by
+// shard, so it is not byte-wise comparable with the ID.
+func (id ID) ToTimeUUID() [16]byte {
+	unixNano := id.Time().UnixNano()
+	ts := uint64(unixNano/100) + gregorianOffset
+	seq := uint16(unixNano%100)<<6 | id.Counter()
+	return [16]byte{
+		// time_low
+		byte(ts >> 24), byte(ts >> 16), byte(ts >> 8), byte(ts),
+		// time_mid
+		byte(ts >> 40), byte(ts >> 32),
+		// time_hi_and_version
+		0x10 | byte(ts>>56)&0x0f, byte(ts >> 48),
+		// clock_seq_hi_and_reserved, clock_seq_low
+		0x80 | byte(seq>>8)&0x3f, byte(seq),
+		// node
+		id[0], id[1], id[2], id[3], id[14], id[15],
+	}
+}
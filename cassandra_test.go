@@ -0,0 +1,62 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCassandraUUID(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	b := id.MarshalCassandraUUID()
+	if string(b[:]) != string(id[:]) {
+		t.Fatalf("expect %x got %x", id[:], b[:])
+	}
+	if id2 := UnmarshalCassandraUUID(b); id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+}
+
+func TestTimeUUID(t *testing.T) {
+	p := NewProcess(0x0304)
+	id, err := p.NewIDWithNamespace(0x0102, 0xabcd, time.Date(2100, 1, 2, 3, 4, 5, 123456789, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id = p.NewID(0x0102, id.Time()).SetNamespace(0xabcd) // counter 1
+	u := id.ToTimeUUID()
+
+	if version := u[6] >> 4; version != 1 {
+		t.Fatalf("expect version 1 got %d", version)
+	}
+	if variant := u[8] >> 6; variant != 2 {
+		t.Fatalf("expect variant 2 got %d", variant)
+	}
+	ts := uint64(u[6]&0x0f)<<56 | uint64(u[7])<<48 |
+		uint64(u[4])<<40 | uint64(u[5])<<32 |
+		uint64(u[0])<<24 | uint64(u[1])<<16 | uint64(u[2])<<8 | uint64(u[3])
+	seq := uint16(u[8]&0x3f)<<8 | uint16(u[9])
+	unixNano := int64(ts-gregorianOffset)*100 + int64(seq>>6)
+	if tm := time.Unix(0, unixNano).UTC(); !tm.Equal(id.Time()) {
+		t.Fatalf("expect %v got %v", id.Time(), tm)
+	}
+	if counter := seq & 0x3f; counter != 1 {
+		t.Fatalf("expect 1 got %d", counter)
+	}
+	if node := [6]byte{u[10], u[11], u[12], u[13], u[14], u[15]}; node != [6]byte{0x01, 0x02, 0xab, 0xcd, 0x03, 0x04} {
+		t.Fatalf("expect 0102abcd0304 got %x", node)
+	}
+}
+
+func TestTimeUUIDOrder(t *testing.T) {
+	p := NewProcess(2)
+	id1 := p.NewID(0xffff, time.Now())
+	id2 := p.NewID(0, time.Now())
+	u1, u2 := id1.ToTimeUUID(), id2.ToTimeUUID()
+	ts := func(u [16]byte) uint64 {
+		return uint64(u[6]&0x0f)<<56 | uint64(u[7])<<48 | uint64(u[4])<<40 | uint64(u[5])<<32 |
+			uint64(u[0])<<24 | uint64(u[1])<<16 | uint64(u[2])<<8 | uint64(u[3])
+	}
+	if ts(u1) > ts(u2) {
+		t.Fatalf("expect %x not after %x", u1, u2)
+	}
+}
@@ -0,0 +1,31 @@
+package buid
+
+import (
+	"fmt"
+	"time"
+)
+
+// CheckShard returns an error if the embedded shard index is not expected
+func (id ID) CheckShard(expected uint16) error {
+	if shard := id.Shard(); shard != expected {
+		return fmt.Errorf("BUID %s belongs to shard %d, not %d", id, shard, expected)
+	}
+	return nil
+}
+
+// CheckProcess returns an error if the embedded process ID is not expected
+func (id ID) CheckProcess(expected uint16) error {
+	if process := id.Process(); process != expected {
+		return fmt.Errorf("BUID %s is generated by process %d, not %d", id, process, expected)
+	}
+	return nil
+}
+
+// CheckTimeRange returns an error if the embedded timestamp is not within
+// [lo, hi]
+func (id ID) CheckTimeRange(lo, hi time.Time) error {
+	if t := id.Time(); t.Before(lo) || t.After(hi) {
+		return fmt.Errorf("BUID %s has time %v out of range [%v, %v]", id, t, lo, hi)
+	}
+	return nil
+}
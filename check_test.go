@@ -0,0 +1,50 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckShard(t *testing.T) {
+	id := NewProcess(1).NewID(42, time.Now())
+	if err := id.CheckShard(42); err != nil {
+		t.Fatal(err)
+	}
+	if err := id.CheckShard(43); err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestCheckProcess(t *testing.T) {
+	id := NewProcess(7).NewID(42, time.Now())
+	if err := id.CheckProcess(7); err != nil {
+		t.Fatal(err)
+	}
+	if err := id.CheckProcess(8); err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestCheckTimeRange(t *testing.T) {
+	process := NewProcess(1)
+	ts := externalTime(process.t).Add(time.Second)
+	id := process.NewID(42, ts)
+	for _, r := range [][2]time.Time{
+		{ts, ts},
+		{ts.Add(-time.Second), ts},
+		{ts, ts.Add(time.Second)},
+	} {
+		if err := id.CheckTimeRange(r[0], r[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range [][2]time.Time{
+		{ts.Add(time.Nanosecond), ts.Add(time.Second)},
+		{ts.Add(-time.Second), ts.Add(-time.Nanosecond)},
+		{ts.Add(time.Second), ts.Add(-time.Second)},
+	} {
+		if err := id.CheckTimeRange(r[0], r[1]); err == nil {
+			t.Fatalf("expect error for [%v, %v]", r[0], r[1])
+		}
+	}
+}
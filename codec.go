@@ -0,0 +1,52 @@
+package buid
+
+import (
+	"errors"
+
+	"h12.io/buid/basex"
+)
+
+// Codec encodes BUIDs to strings of a custom alphabet, with the same leading
+// zero compression as the base-62 text encoding
+type Codec struct {
+	encoding *basex.Encoding
+}
+
+// NewCodec returns a Codec of the alphabet, which must contain at least 2
+// characters without duplication, ordered from the digit of 0
+func NewCodec(alphabet string) (*Codec, error) {
+	if len([]rune(alphabet)) < 2 {
+		return nil, errors.New("alphabet must contain at least 2 characters")
+	}
+	encoding, err := basex.NewEncoding(alphabet)
+	if err != nil {
+		return nil, err
+	}
+	return &Codec{encoding: encoding}, nil
+}
+
+// Encode returns the encoded string of id, or an empty string if id is zero,
+// the same as ID.String for the base-62 alphabet
+func (c *Codec) Encode(id ID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return c.encoding.Encode(id[:])
+}
+
+// Decode parses a string returned by Encode
+func (c *Codec) Decode(s string) (ID, error) {
+	var id ID
+	if s == "" {
+		return id, nil
+	}
+	data, err := c.encoding.Decode(s)
+	if err != nil {
+		return id, err
+	}
+	if len(data) != len(id) {
+		return id, errors.New("BUID length must be 128 bit")
+	}
+	copy(id[:], data)
+	return id, nil
+}
@@ -0,0 +1,87 @@
+package buid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCodec(t *testing.T) {
+	p := NewProcess(0xabcd)
+	ids := append(p.NewIDs(2, time.Now(), 3), ID{}, IDFields{Process: 1}.ID(), MaxIDForShard(0xffff, time.Now()))
+	for _, c := range []struct {
+		name     string
+		alphabet string
+	}{
+		{"base62", base62Alphabet},
+		{"lower", "0123456789abcdefghijklmnopqrstuvwxyz"},
+		{"binary", "01"},
+	} {
+		codec, err := NewCodec(c.alphabet)
+		if err != nil {
+			t.Fatal(c.name, err)
+		}
+		for _, id := range ids {
+			s := codec.Encode(id)
+			if c.alphabet == base62Alphabet && s != id.String() {
+				t.Fatalf("%s: expect %s got %s", c.name, id.String(), s)
+			}
+			if strings.Trim(s, c.alphabet) != "" {
+				t.Fatalf("%s: expect only characters of %s got %s", c.name, c.alphabet, s)
+			}
+			decoded, err := codec.Decode(s)
+			if err != nil {
+				t.Fatal(c.name, err)
+			}
+			if decoded != id {
+				t.Fatalf("%s: expect %v got %v", c.name, id, decoded)
+			}
+		}
+	}
+}
+
+func TestCodecBinary(t *testing.T) {
+	codec, err := NewCodec("01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := IDFields{ShardIndex: 0x8000, Process: 1}.ID()
+	if s := codec.Encode(id); s != "1"+strings.Repeat("0", 126)+"1" {
+		t.Fatalf("expect 128 bits got %s", s)
+	}
+}
+
+func TestCodecMultiByteAlphabet(t *testing.T) {
+	codec, err := NewCodec("αβγδ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := NewProcess(0xabcd).NewID(2, time.Now())
+	decoded, err := codec.Decode(codec.Encode(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != id {
+		t.Fatalf("expect %v got %v", id, decoded)
+	}
+	if _, err := codec.Decode("αé"); err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestCodecError(t *testing.T) {
+	for _, alphabet := range []string{"", "0", "0120"} {
+		if _, err := NewCodec(alphabet); err == nil {
+			t.Fatalf("expect error for alphabet %q", alphabet)
+		}
+	}
+	codec, err := NewCodec("0123456789abcdefghijklmnopqrstuvwxyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"A", "abc"} {
+		if _, err := codec.Decode(s); err == nil {
+			t.Fatalf("expect error for %q", s)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package buid
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// ConsistentShard returns the shard index in [0, numShards) for an entity key
+// with jump consistent hashing (Lamping and Veach, 2014) over its FNV-64a hash
+//
+// When numShards grows from n to n+1, only about 1/(n+1) of the keys move, and
+// all of them move to the new shard. It returns 0 if numShards is 0.
+func ConsistentShard(key []byte, numShards uint16) uint16 {
+	h := fnv.New64a()
+	h.Write(key)
+	k := h.Sum64()
+	var b, j int64 = -1, 0
+	for j < int64(numShards) {
+		b = j
+		k = k*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((k>>33)+1)))
+	}
+	if b < 0 {
+		return 0
+	}
+	return uint16(b)
+}
+
+// NewIDForKey generates a new BUID in the shard of entityKey chosen by
+// ConsistentShard
+func (p *Process) NewIDForKey(entityKey []byte, numShards uint16, t time.Time) ID {
+	return p.NewID(ConsistentShard(entityKey, numShards), t)
+}
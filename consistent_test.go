@@ -0,0 +1,52 @@
+package buid
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestConsistentShard(t *testing.T) {
+	key := []byte("user-1")
+	if s := ConsistentShard(key, 0); s != 0 {
+		t.Fatalf("expect 0 got %d", s)
+	}
+	if s := ConsistentShard(key, 1); s != 0 {
+		t.Fatalf("expect 0 got %d", s)
+	}
+	for _, n := range []uint16{2, 10, 0xffff} {
+		if s1, s2 := ConsistentShard(key, n), ConsistentShard(key, n); s1 != s2 || s1 >= n {
+			t.Fatalf("expect the same shard below %d got %d and %d", n, s1, s2)
+		}
+	}
+}
+
+func TestConsistentShardMoves(t *testing.T) {
+	const keys = 10000
+	for _, n := range []uint16{1, 4, 10, 100} {
+		moved := 0
+		for i := 0; i < keys; i++ {
+			key := []byte(strconv.Itoa(i))
+			before, after := ConsistentShard(key, n), ConsistentShard(key, n+1)
+			if before != after {
+				if after != n {
+					t.Fatalf("expect key %d to move to new shard %d got %d", i, n, after)
+				}
+				moved++
+			}
+		}
+		expected := float64(keys) / float64(n+1)
+		if math.Abs(float64(moved)-expected) > 0.2*expected {
+			t.Fatalf("expect about %.0f keys moved from %d shards got %d", expected, n, moved)
+		}
+	}
+}
+
+func TestNewIDForKey(t *testing.T) {
+	key := []byte("user-1")
+	id := NewProcess(2).NewIDForKey(key, 16, time.Now())
+	if s := ConsistentShard(key, 16); id.Shard() != s {
+		t.Fatalf("expect %d got %d", s, id.Shard())
+	}
+}
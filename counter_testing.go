@@ -0,0 +1,15 @@
+//go:build testing
+
+package buid
+
+// SetCounter forces the counter of the current nanosecond to c, so that a
+// replay generates the same IDs as a recorded run
+//
+// It is only for deterministic tests and is unsafe in production: a counter
+// set backwards produces duplicate IDs. It is only built with the "testing"
+// build tag.
+func (p *Process) SetCounter(c uint8) {
+	p.mu.Lock()
+	p.counter = c
+	p.mu.Unlock()
+}
@@ -0,0 +1,28 @@
+//go:build testing
+
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetCounter(t *testing.T) {
+	p := NewProcess(2)
+	ts := externalTime(p.t).Add(time.Second)
+	expected := make([]ID, 3)
+	for i := range expected {
+		expected[i] = p.NewID(1, ts)
+	}
+
+	p.SetCounter(0)
+	for i := range expected {
+		if id := p.NewID(1, ts); id != expected[i] {
+			t.Fatalf("expect %v got %v", expected[i], id)
+		}
+	}
+	p.SetCounter(5)
+	if id := p.NewID(1, ts); id.Counter() != 5 {
+		t.Fatalf("expect 5 got %d", id.Counter())
+	}
+}
@@ -0,0 +1,39 @@
+package buid
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelativeToSibling decomposes the difference between id and sibling into
+// each embedded field, each delta being the value of id minus that of sibling
+//
+// It shows at a glance whether two IDs come from the same process, the same
+// nanosecond, etc.
+func (id ID) RelativeToSibling(sibling ID) (timeDelta time.Duration, shardDelta, processDelta, counterDelta int) {
+	timeDelta = id.Time().Sub(sibling.Time())
+	shardDelta = int(id.Shard()) - int(sibling.Shard())
+	processDelta = int(id.Process()) - int(sibling.Process())
+	counterDelta = int(id.Counter()) - int(sibling.Counter())
+	return
+}
+
+// Verbose returns all embedded fields in a single line for logs and test
+// failures, e.g.
+//
+//	BUID{shard=3, time=2024-01-15T12:34:56.123456789Z, hour=54588, minute=34, second=56, nanosecond=123456789, counter=7, process=42}
+//
+// The hour is counted from Epoch.
+func (id ID) Verbose() string {
+	t := id.Time()
+	return fmt.Sprintf("BUID{shard=%d, time=%s, hour=%d, minute=%d, second=%d, nanosecond=%d, counter=%d, process=%d}",
+		id.Shard(),
+		t.Format(time.RFC3339Nano),
+		internalTime(t)/hourInNano,
+		t.Minute(),
+		t.Second(),
+		t.Nanosecond(),
+		id.Counter(),
+		id.Process(),
+	)
+}
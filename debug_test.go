@@ -0,0 +1,61 @@
+package buid
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRelativeToSibling(t *testing.T) {
+	process := NewProcess(1)
+	ts := externalTime(process.t).Add(time.Second)
+	id1 := process.NewID(2, ts)
+	id2 := process.NewID(2, ts)
+	id3 := process.NewID(2, ts.Add(3*time.Nanosecond))
+
+	// same nanosecond: only the counter differs
+	timeDelta, shardDelta, processDelta, counterDelta := id2.RelativeToSibling(id1)
+	if timeDelta != 0 || shardDelta != 0 || processDelta != 0 || counterDelta != 1 {
+		t.Fatalf("unexpected %v, %d, %d, %d", timeDelta, shardDelta, processDelta, counterDelta)
+	}
+
+	// 3 nanoseconds later: the counter is reset
+	timeDelta, shardDelta, processDelta, counterDelta = id3.RelativeToSibling(id2)
+	if timeDelta != 3*time.Nanosecond || shardDelta != 0 || processDelta != 0 || counterDelta != -1 {
+		t.Fatalf("unexpected %v, %d, %d, %d", timeDelta, shardDelta, processDelta, counterDelta)
+	}
+
+	// a different process and shard
+	other := NewProcess(5).NewID(7, ts)
+	timeDelta, shardDelta, processDelta, counterDelta = id1.RelativeToSibling(other)
+	if timeDelta != 0 || shardDelta != -5 || processDelta != -4 || counterDelta != 0 {
+		t.Fatalf("unexpected %v, %d, %d, %d", timeDelta, shardDelta, processDelta, counterDelta)
+	}
+}
+
+func TestVerbose(t *testing.T) {
+	process := NewProcess(42)
+	ts := time.Date(2100, 1, 15, 12, 34, 56, 123456789, time.UTC)
+	id := process.NewID(3, ts)
+	s := id.Verbose()
+	expected := fmt.Sprintf("BUID{shard=3, time=2100-01-15T12:34:56.123456789Z, hour=%d, minute=34, second=56, nanosecond=123456789, counter=0, process=42}",
+		internalTime(ts.Truncate(time.Hour))/hourInNano)
+	if s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+	for _, field := range []string{"shard=", "time=", "hour=", "minute=", "second=", "nanosecond=", "counter=", "process="} {
+		if !strings.Contains(s, field) {
+			t.Fatalf("expect %s in %s", field, s)
+		}
+	}
+	start := strings.Index(s, "time=") + len("time=")
+	end := start + strings.Index(s[start:], ",")
+	tm, err := time.Parse(time.RFC3339Nano, s[start:end])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tm.Equal(id.Time()) {
+		t.Fatalf("expect %v got %v", id.Time(), tm)
+	}
+}
@@ -0,0 +1,116 @@
+package buid
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DeduplicatorStore stores the IDs of requests seen recently
+type DeduplicatorStore interface {
+	// Contains returns whether id has been added and has not expired
+	Contains(id ID) bool
+	// Add adds id until expires
+	Add(id ID, expires time.Time)
+}
+
+// MemoryDeduplicatorStore is an in-memory DeduplicatorStore, it removes the
+// expired IDs in a background goroutine until closed
+type MemoryDeduplicatorStore struct {
+	mu      sync.Mutex
+	expires map[ID]time.Time
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewMemoryDeduplicatorStore returns a new MemoryDeduplicatorStore removing
+// the expired IDs every cleanupInterval
+//
+// If cleanupInterval is not positive, there is no background cleanup: expired
+// IDs are still ignored by Contains but stay in memory until overwritten.
+func NewMemoryDeduplicatorStore(cleanupInterval time.Duration) *MemoryDeduplicatorStore {
+	s := &MemoryDeduplicatorStore{
+		expires: make(map[ID]time.Time),
+		done:    make(chan struct{}),
+	}
+	if cleanupInterval > 0 {
+		go s.cleanup(cleanupInterval)
+	}
+	return s
+}
+
+// Contains implements DeduplicatorStore
+func (s *MemoryDeduplicatorStore) Contains(id ID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expires, ok := s.expires[id]
+	return ok && time.Now().Before(expires)
+}
+
+// Add implements DeduplicatorStore
+func (s *MemoryDeduplicatorStore) Add(id ID, expires time.Time) {
+	s.mu.Lock()
+	s.expires[id] = expires
+	s.mu.Unlock()
+}
+
+// Len returns the number of stored IDs including the expired ones not yet
+// removed
+func (s *MemoryDeduplicatorStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.expires)
+}
+
+// Close stops the background cleanup
+func (s *MemoryDeduplicatorStore) Close() error {
+	s.once.Do(func() { close(s.done) })
+	return nil
+}
+
+func (s *MemoryDeduplicatorStore) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for id, expires := range s.expires {
+				if !now.Before(expires) {
+					delete(s.expires, id)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// DeduplicateHandler returns a handler that reads the request ID from header
+// and rejects a duplicate seen within ttl with 409 Conflict
+//
+// A missing or invalid request ID is rejected with 400 Bad Request. The check
+// and the add are done under a lock, so that concurrent duplicates are still
+// rejected by a store without an atomic check-and-add.
+func DeduplicateHandler(next http.Handler, store DeduplicatorStore, ttl time.Duration, header string) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := ParseID(r.Header.Get(header))
+		if err != nil {
+			http.Error(w, "invalid request ID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		duplicate := store.Contains(id)
+		if !duplicate {
+			store.Add(id, time.Now().Add(ttl))
+		}
+		mu.Unlock()
+		if duplicate {
+			http.Error(w, "duplicate request ID "+id.String(), http.StatusConflict)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
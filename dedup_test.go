@@ -0,0 +1,139 @@
+package buid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testRequestIDHeader = "X-Request-ID"
+
+func testDeduplicateHandler(store DeduplicatorStore, ttl time.Duration) (http.Handler, *int64) {
+	var served int64
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&served, 1)
+	})
+	return DeduplicateHandler(next, store, ttl, testRequestIDHeader), &served
+}
+
+func serveRequestID(h http.Handler, requestID string) int {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set(testRequestIDHeader, requestID)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestDeduplicateHandler(t *testing.T) {
+	store := NewMemoryDeduplicatorStore(time.Minute)
+	defer store.Close()
+	h, served := testDeduplicateHandler(store, time.Minute)
+	p := NewProcess(1)
+	id1, id2 := p.NewIDNow(1), p.NewIDNow(1)
+
+	for _, tc := range []struct {
+		requestID string
+		code      int
+	}{
+		{id1.String(), http.StatusOK},
+		{id1.String(), http.StatusConflict},
+		{id2.String(), http.StatusOK},
+		{id1.String(), http.StatusConflict},
+		{"", http.StatusBadRequest},
+		{"!", http.StatusBadRequest},
+	} {
+		if code := serveRequestID(h, tc.requestID); code != tc.code {
+			t.Fatalf("%q: expect %d got %d", tc.requestID, tc.code, code)
+		}
+	}
+	if *served != 2 {
+		t.Fatalf("expect 2 served got %d", *served)
+	}
+}
+
+func TestDeduplicateHandlerExpiry(t *testing.T) {
+	store := NewMemoryDeduplicatorStore(time.Millisecond)
+	defer store.Close()
+	h, served := testDeduplicateHandler(store, 10*time.Millisecond)
+	id := NewProcess(1).NewIDNow(1)
+	if code := serveRequestID(h, id.String()); code != http.StatusOK {
+		t.Fatalf("expect %d got %d", http.StatusOK, code)
+	}
+	if code := serveRequestID(h, id.String()); code != http.StatusConflict {
+		t.Fatalf("expect %d got %d", http.StatusConflict, code)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if code := serveRequestID(h, id.String()); code != http.StatusOK {
+		t.Fatalf("expect %d got %d", http.StatusOK, code)
+	}
+	if *served != 2 {
+		t.Fatalf("expect 2 served got %d", *served)
+	}
+}
+
+func TestMemoryDeduplicatorStoreCleanup(t *testing.T) {
+	store := NewMemoryDeduplicatorStore(time.Millisecond)
+	defer store.Close()
+	id := NewProcess(1).NewIDNow(1)
+	store.Add(id, time.Now().Add(5*time.Millisecond))
+	if !store.Contains(id) {
+		t.Fatal("expect contained")
+	}
+	deadline := time.Now().Add(time.Second)
+	for store.Len() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expect the expired ID removed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if store.Contains(id) {
+		t.Fatal("expect not contained")
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemoryDeduplicatorStoreNoCleanup(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		store := NewMemoryDeduplicatorStore(interval)
+		id := NewProcess(1).NewIDNow(1)
+		store.Add(id, time.Now().Add(-time.Nanosecond))
+		if store.Contains(id) {
+			t.Fatal("expect the expired ID not contained")
+		}
+		if store.Len() != 1 {
+			t.Fatalf("expect 1 got %d", store.Len())
+		}
+		if err := store.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDeduplicateHandlerConcurrent(t *testing.T) {
+	store := NewMemoryDeduplicatorStore(time.Minute)
+	defer store.Close()
+	h, served := testDeduplicateHandler(store, time.Minute)
+	id := NewProcess(1).NewIDNow(1)
+	var (
+		wg        sync.WaitGroup
+		conflicts int64
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if serveRequestID(h, id.String()) == http.StatusConflict {
+				atomic.AddInt64(&conflicts, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if *served != 1 || conflicts != 99 {
+		t.Fatalf("expect 1 served and 99 conflicts got %d and %d", *served, conflicts)
+	}
+}
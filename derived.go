@@ -0,0 +1,36 @@
+package buid
+
+import "encoding/json"
+
+// DerivedID links a child ID to the parent ID it is derived from, e.g. an
+// event to the command that generates it
+type DerivedID struct {
+	Parent ID
+	Child  ID
+}
+
+type derivedIDJSON struct {
+	Parent ID `json:"parent"`
+	Child  ID `json:"child"`
+}
+
+// NewDerivedID generates a new child ID of parent in shard
+//
+// shard is usually parent.Shard(), so that the child is stored together with
+// the parent.
+func NewDerivedID(parent ID, p *Process, shard uint16) DerivedID {
+	return DerivedID{
+		Parent: parent,
+		Child:  p.NewIDNow(shard),
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (d DerivedID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(derivedIDJSON(d))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *DerivedID) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*derivedIDJSON)(d))
+}
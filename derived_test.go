@@ -0,0 +1,55 @@
+package buid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNewDerivedID(t *testing.T) {
+	parent := NewProcess(1).NewID(42, time.Now())
+	p := NewProcess(2)
+	d1 := NewDerivedID(parent, p, parent.Shard())
+	d2 := NewDerivedID(parent, p, parent.Shard())
+	if d1.Parent != parent || d2.Parent != parent {
+		t.Fatal("expect parent to be kept")
+	}
+	if d1.Child == d2.Child {
+		t.Fatal("expect unique children")
+	}
+	if d1.Child.Shard() != 42 || d1.Child.Process() != 2 {
+		t.Fatalf("unexpected child %v", d1.Child)
+	}
+}
+
+func TestNewDerivedIDWithClock(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	p := NewProcessWithOptions(WithProcessID(2), WithInitialTime(ts.Add(-time.Hour)), WithClock(func() time.Time { return ts }))
+	d := NewDerivedID(NewProcess(1).NewID(42, time.Now()), p, 42)
+	if !d.Child.Time().Equal(ts) {
+		t.Fatalf("expect %v got %v", ts, d.Child.Time())
+	}
+}
+
+func TestDerivedIDJSON(t *testing.T) {
+	parent := NewProcess(1).NewID(42, time.Now())
+	d1 := NewDerivedID(parent, NewProcess(2), parent.Shard())
+	buf, err := json.Marshal(d1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"parent":"` + d1.Parent.String() + `","child":"` + d1.Child.String() + `"}`
+	if string(buf) != expected {
+		t.Fatalf("expect %s got %s", expected, buf)
+	}
+	var d2 DerivedID
+	if err := json.Unmarshal(buf, &d2); err != nil {
+		t.Fatal(err)
+	}
+	if d1 != d2 {
+		t.Fatalf("expect %v got %v", d1, d2)
+	}
+	if err := json.Unmarshal([]byte(`{"parent":"!"}`), &d2); err == nil {
+		t.Fatal("expect error")
+	}
+}
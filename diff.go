@@ -0,0 +1,65 @@
+package buid
+
+import "errors"
+
+// IDDiff is the XOR of two IDs
+type IDDiff [16]byte
+
+// Diff returns the XOR of base and target
+//
+// Consecutive IDs share most of their bytes (the same shard and a close time),
+// so their diff is mostly zero bytes and compresses well with DiffRLE.
+func Diff(base, target ID) IDDiff {
+	var diff IDDiff
+	for i := range diff {
+		diff[i] = base[i] ^ target[i]
+	}
+	return diff
+}
+
+// ApplyDiff reconstructs the target ID from base and the diff returned by Diff
+func ApplyDiff(base ID, diff IDDiff) ID {
+	var target ID
+	for i := range target {
+		target[i] = base[i] ^ diff[i]
+	}
+	return target
+}
+
+// DiffRLE run-length encodes diff as pairs of a run length and a byte value
+func DiffRLE(diff IDDiff) []byte {
+	var encoded []byte
+	for i := 0; i < len(diff); {
+		j := i + 1
+		for j < len(diff) && diff[j] == diff[i] {
+			j++
+		}
+		encoded = append(encoded, byte(j-i), diff[i])
+		i = j
+	}
+	return encoded
+}
+
+// ApplyDiffRLE reconstructs the target ID from base and the diff encoded by
+// DiffRLE
+func ApplyDiffRLE(base ID, encoded []byte) (ID, error) {
+	if len(encoded)%2 != 0 {
+		return ID{}, errors.New("RLE diff length must be even")
+	}
+	var diff IDDiff
+	n := 0
+	for i := 0; i < len(encoded); i += 2 {
+		run, value := int(encoded[i]), encoded[i+1]
+		if run == 0 || n+run > len(diff) {
+			return ID{}, errors.New("invalid RLE diff run length")
+		}
+		for j := 0; j < run; j++ {
+			diff[n] = value
+			n++
+		}
+	}
+	if n != len(diff) {
+		return ID{}, errors.New("RLE diff must decode to 128 bit")
+	}
+	return ApplyDiff(base, diff), nil
+}
@@ -0,0 +1,65 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	p := NewProcess(2)
+	base := p.NewID(1, time.Now())
+	target := p.NewID(1, time.Now())
+	diff := Diff(base, target)
+	if id := ApplyDiff(base, diff); id != target {
+		t.Fatalf("expect %v got %v", target, id)
+	}
+	if diff := Diff(base, base); diff != (IDDiff{}) {
+		t.Fatalf("expect zero diff got %x", diff)
+	}
+}
+
+func TestDiffRLE(t *testing.T) {
+	p := NewProcess(2)
+	base := p.NewID(1, time.Now())
+	target := p.NewID(1, time.Now())
+	encoded := DiffRLE(Diff(base, target))
+	if len(encoded) >= 16 {
+		t.Fatalf("expect consecutive IDs to compress got %d bytes", len(encoded))
+	}
+	id, err := ApplyDiffRLE(base, encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != target {
+		t.Fatalf("expect %v got %v", target, id)
+	}
+
+	if encoded := DiffRLE(IDDiff{}); string(encoded) != "\x10\x00" {
+		t.Fatalf("expect 1000 got %x", encoded)
+	}
+	var diff IDDiff
+	for i := range diff {
+		diff[i] = byte(i)
+	}
+	if encoded := DiffRLE(diff); len(encoded) != 32 {
+		t.Fatalf("expect 32 bytes got %d", len(encoded))
+	}
+	if id, err := ApplyDiffRLE(base, DiffRLE(diff)); err != nil || id != ApplyDiff(base, diff) {
+		t.Fatalf("expect %v got %v, %v", ApplyDiff(base, diff), id, err)
+	}
+}
+
+func TestApplyDiffRLEError(t *testing.T) {
+	for _, encoded := range [][]byte{
+		nil,
+		{0x10},
+		{0x00, 0x01, 0x10, 0x00},
+		{0x0f, 0x00},
+		{0x10, 0x00, 0x01, 0x00},
+		{0x11, 0x00},
+	} {
+		if _, err := ApplyDiffRLE(ID{}, encoded); err == nil {
+			t.Fatalf("expect error for %x", encoded)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Package dot renders BUIDs as DOT (Graphviz) nodes and edges for visualizing
+// causal graphs of IDs
+package dot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"h12.io/buid"
+)
+
+// ToGraphNode returns the DOT node declaration of id labelled with its
+// embedded fields
+func ToGraphNode(id buid.ID) string {
+	return fmt.Sprintf(`"%s" [label="shard=%d\ntime=%s\nproc=%d\nctr=%d"];`,
+		id, id.Shard(), id.Time().Format(time.RFC3339Nano), id.Process(), id.Counter())
+}
+
+// ToGraphEdge returns the DOT edge declaration from id to other with label
+func ToGraphEdge(id, other buid.ID, label string) string {
+	return fmt.Sprintf(`"%s" -> "%s" [label="%s"];`, id, other, escape(label))
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
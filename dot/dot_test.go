@@ -0,0 +1,26 @@
+package dot
+
+import (
+	"testing"
+	"time"
+
+	"h12.io/buid"
+)
+
+func TestToGraphNode(t *testing.T) {
+	ts := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := buid.NewProcess(7).NewID(3, ts)
+	expected := `"` + id.String() + `" [label="shard=3\ntime=2100-01-01T00:00:00Z\nproc=7\nctr=0"];`
+	if node := ToGraphNode(id); node != expected {
+		t.Fatalf("expect %s got %s", expected, node)
+	}
+}
+
+func TestToGraphEdge(t *testing.T) {
+	p := buid.NewProcess(7)
+	id1, id2 := p.NewID(3, time.Now()), p.NewID(3, time.Now())
+	expected := `"` + id1.String() + `" -> "` + id2.String() + `" [label="emits \"created\""];`
+	if edge := ToGraphEdge(id1, id2, `emits "created"`); edge != expected {
+		t.Fatalf("expect %s got %s", expected, edge)
+	}
+}
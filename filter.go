@@ -0,0 +1,52 @@
+package buid
+
+import "time"
+
+// IsFromProcess returns whether the ID was generated by the process
+func (id ID) IsFromProcess(processID uint16) bool {
+	return id.Process() == processID
+}
+
+// IsFromShard returns whether the ID is of the shard index
+func (id ID) IsFromShard(shardIndex uint16) bool {
+	return id.Shard() == shardIndex
+}
+
+// IsFromProcess returns a predicate of ID.IsFromProcess for filtering
+func IsFromProcess(processID uint16) func(ID) bool {
+	return func(id ID) bool { return id.IsFromProcess(processID) }
+}
+
+// IsFromShard returns a predicate of ID.IsFromShard for filtering
+func IsFromShard(shardIndex uint16) func(ID) bool {
+	return func(id ID) bool { return id.IsFromShard(shardIndex) }
+}
+
+// InTimeRange returns whether from <= id.Time() <= to, which is always false
+// if from is after to
+func (id ID) InTimeRange(from, to time.Time) bool {
+	t := id.Time()
+	return !t.Before(from) && !t.After(to)
+}
+
+// FilterByTimeRange returns a new slice of the ids with from <= id.Time() <= to
+func FilterByTimeRange(ids []ID, from, to time.Time) []ID {
+	res := make([]ID, 0)
+	for _, id := range ids {
+		if id.InTimeRange(from, to) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// FilterByShard returns a new slice of the ids of the shard index
+func FilterByShard(ids []ID, shardIdx uint16) []ID {
+	res := make([]ID, 0)
+	for _, id := range ids {
+		if id.IsFromShard(shardIdx) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
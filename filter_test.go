@@ -0,0 +1,101 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func filterIDs(ids []ID, pred func(ID) bool) []ID {
+	var res []ID
+	for _, id := range ids {
+		if pred(id) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func TestIsFromProcess(t *testing.T) {
+	p1, p2 := NewProcess(42), NewProcess(43)
+	id1, id2 := p1.NewIDNow(1), p2.NewIDNow(1)
+	if !id1.IsFromProcess(42) || id1.IsFromProcess(43) || !id2.IsFromProcess(43) {
+		t.Fatal("unexpected process attribution")
+	}
+	if res := filterIDs([]ID{id1, id2, id1}, IsFromProcess(42)); len(res) != 2 || res[0] != id1 || res[1] != id1 {
+		t.Fatalf("expect [%v %v] got %v", id1, id1, res)
+	}
+}
+
+func TestIsFromShard(t *testing.T) {
+	p := NewProcess(1)
+	id1, id2 := p.NewID(3, time.Now()), p.NewID(4, time.Now())
+	if !id1.IsFromShard(3) || id1.IsFromShard(4) || !id2.IsFromShard(4) {
+		t.Fatal("unexpected shard attribution")
+	}
+	if res := filterIDs([]ID{id1, id2}, IsFromShard(4)); len(res) != 1 || res[0] != id2 {
+		t.Fatalf("expect [%v] got %v", id2, res)
+	}
+}
+
+func TestFilterByTimeRange(t *testing.T) {
+	base := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	p := NewProcess(1)
+	var ids []ID
+	for i := 0; i < 5; i++ {
+		ids = append(ids, p.NewID(1, base.Add(time.Duration(i)*time.Second)))
+	}
+	orig := append([]ID(nil), ids...)
+
+	res := FilterByTimeRange(ids, base.Add(time.Second), base.Add(3*time.Second))
+	if len(res) != 3 || res[0] != ids[1] || res[2] != ids[3] {
+		t.Fatalf("expect %v got %v", ids[1:4], res)
+	}
+	res = FilterByTimeRange(ids, base.Add(2*time.Second), base.Add(2*time.Second))
+	if len(res) != 1 || res[0] != ids[2] {
+		t.Fatalf("expect [%v] got %v", ids[2], res)
+	}
+	if res := FilterByTimeRange(ids, base.Add(time.Hour), base.Add(2*time.Hour)); res == nil || len(res) != 0 {
+		t.Fatalf("expect an empty slice got %v", res)
+	}
+
+	res = FilterByTimeRange(ids, base, base.Add(time.Hour))
+	res[0] = ID{}
+	for i := range ids {
+		if ids[i] != orig[i] {
+			t.Fatal("expect the input unmodified")
+		}
+	}
+}
+
+func TestFilterByShard(t *testing.T) {
+	p := NewProcess(1)
+	ids := []ID{p.NewIDNow(1), p.NewIDNow(2), p.NewIDNow(1)}
+	res := FilterByShard(ids, 1)
+	if len(res) != 2 || res[0] != ids[0] || res[1] != ids[2] {
+		t.Fatalf("expect [%v %v] got %v", ids[0], ids[2], res)
+	}
+	if res := FilterByShard(ids, 3); res == nil || len(res) != 0 {
+		t.Fatalf("expect an empty slice got %v", res)
+	}
+}
+
+func TestInTimeRange(t *testing.T) {
+	base := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	id := NewProcess(1).NewID(1, base)
+	for _, tc := range []struct {
+		from, to time.Time
+		expected bool
+	}{
+		{base, base.Add(time.Second), true},
+		{base.Add(-time.Second), base.Add(time.Second), true},
+		{base.Add(-time.Second), base, true},
+		{base, base, true},
+		{base.Add(time.Nanosecond), base.Add(time.Second), false},
+		{base.Add(-time.Second), base.Add(-time.Nanosecond), false},
+		{base.Add(time.Second), base.Add(-time.Second), false},
+	} {
+		if ok := id.InTimeRange(tc.from, tc.to); ok != tc.expected {
+			t.Fatalf("[%v, %v]: expect %v got %v", tc.from, tc.to, tc.expected, ok)
+		}
+	}
+}
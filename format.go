@@ -0,0 +1,177 @@
+package buid
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var crockfordEncoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+// Format implements fmt.Formatter
+//
+//	%s, %v  base-62 (same as String)
+//	%+v     all embedded fields (same as Verbose)
+//	%q      double-quoted base-62
+//	%x, %X  lower / upper case hex
+//	%b      the raw 16 bytes
+//	%d      decimal value of the 128-bit big-endian integer
+//	%o      Crockford base-32
+func (id ID) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			s = id.Verbose()
+		} else {
+			s = id.String()
+		}
+	case 's', 'q':
+		s = id.String()
+	case 'x':
+		s = id.Hex()
+	case 'X':
+		s = strings.ToUpper(id.Hex())
+	case 'b':
+		s = string(id[:])
+	case 'd':
+		s = new(big.Int).SetBytes(id[:]).String()
+	case 'o':
+		s = crockfordEncoding.EncodeToString(id[:])
+	default:
+		fmt.Fprintf(f, "%%!%c(buid.ID=%s)", verb, id.String())
+		return
+	}
+	if verb != 'q' {
+		verb = 's'
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), s)
+}
+
+// WriteString writes the base-62 encoded string to sb
+func (id ID) WriteString(sb *strings.Builder) {
+	var buf [22]byte
+	sb.Write(id.AppendText(buf[:0]))
+}
+
+// WriteHex writes the lower case hex encoded string to sb
+func (id ID) WriteHex(sb *strings.Builder) {
+	var buf [32]byte
+	hex.Encode(buf[:], id[:])
+	sb.Write(buf[:])
+}
+
+// MarshalProtoText returns the protobuf text format representation of the ID
+// bytes, with every byte escaped as \xHH
+func (id ID) MarshalProtoText() string {
+	var sb strings.Builder
+	sb.Grow(len(id) * 4)
+	for _, b := range id {
+		sb.WriteString(`\x`)
+		sb.WriteByte(hexDigits[b>>4])
+		sb.WriteByte(hexDigits[b&0x0f])
+	}
+	return sb.String()
+}
+
+const hexDigits = "0123456789abcdef"
+
+// UnmarshalProtoText parses a protobuf text format bytes value, with or
+// without the surrounding quotes
+func UnmarshalProtoText(s string) (ID, error) {
+	var id ID
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		s = s[1 : len(s)-1]
+	}
+	data, err := strconv.Unquote(`"` + s + `"`)
+	if err != nil {
+		return id, fmt.Errorf("invalid protobuf text format bytes %q", s)
+	}
+	if len(data) != len(id) {
+		return id, errors.New("BUID length must be 128 bit")
+	}
+	copy(id[:], data)
+	return id, nil
+}
+
+// MarshalHexWithSeparator returns the lower case hex encoded string with sep
+// inserted between every groupSize bytes, e.g. "0001-0000-0000-5f3a-..."
+func (id ID) MarshalHexWithSeparator(sep byte, groupSize int) string {
+	if groupSize <= 0 || groupSize >= len(id) {
+		return hex.EncodeToString(id[:])
+	}
+	var sb strings.Builder
+	sb.Grow(len(id)*2 + len(id)/groupSize)
+	for i, b := range id {
+		if i > 0 && i%groupSize == 0 {
+			sb.WriteByte(sep)
+		}
+		sb.WriteByte(hexDigits[b>>4])
+		sb.WriteByte(hexDigits[b&0x0f])
+	}
+	return sb.String()
+}
+
+// UnmarshalHexWithSeparator parses a hex encoded string after removing every
+// sep, so any grouping is accepted, including the 8-4-4-4-12 UUID format
+func UnmarshalHexWithSeparator(s string, sep byte) (ID, error) {
+	var id ID
+	s = strings.ReplaceAll(s, string(sep), "")
+	if len(s) != hex.EncodedLen(len(id)) {
+		return id, errors.New("BUID length must be 128 bit")
+	}
+	_, err := hex.Decode(id[:], []byte(s))
+	return id, err
+}
+
+// EncodeForDisplay returns the first maxLen characters of the base-62 string,
+// followed by "…" if truncated
+//
+// The result is for display only and cannot be decoded.
+func (id ID) EncodeForDisplay(maxLen int) string {
+	s := id.String()
+	if maxLen < 0 {
+		maxLen = 0
+	}
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen] + "…"
+}
+
+// EncodeForDisplayPrefixSuffix returns the first prefixLen and the last
+// suffixLen characters of the base-62 string joined by "…", e.g. "0skI…rHO2"
+//
+// The result is for display only and cannot be decoded.
+func (id ID) EncodeForDisplayPrefixSuffix(prefixLen, suffixLen int) string {
+	s := id.String()
+	if prefixLen < 0 {
+		prefixLen = 0
+	}
+	if suffixLen < 0 {
+		suffixLen = 0
+	}
+	if len(s) <= prefixLen+suffixLen {
+		return s
+	}
+	return s[:prefixLen] + "…" + s[len(s)-suffixLen:]
+}
+
+// MarshalAmber returns the base-62 string for embedding in an Amber template
+// string literal
+//
+// No escaping is needed because the base-62 alphabet is [0-9A-Za-z], which has
+// no quote, backslash or interpolation characters.
+func (id ID) MarshalAmber() string {
+	return id.String()
+}
+
+// MarshalHTMLAttribute returns the base-62 string in double quotes as an HTML
+// attribute value, e.g. "0skIcr10rnBGT3wdrHO2"
+func (id ID) MarshalHTMLAttribute() string {
+	return `"` + id.String() + `"`
+}
@@ -0,0 +1,237 @@
+package buid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	id := NewProcess(0xabcd).NewID(0x1234, time.Now())
+	for _, tc := range []struct {
+		format   string
+		expected string
+	}{
+		{"%s", id.String()},
+		{"%v", id.String()},
+		{"%+v", id.Verbose()},
+		{"%q", strconv.Quote(id.String())},
+		{"%x", hex.EncodeToString(id[:])},
+		{"%X", strings.ToUpper(hex.EncodeToString(id[:]))},
+		{"%b", string(id[:])},
+		{"%d", new(big.Int).SetBytes(id[:]).String()},
+		{"%o", crockfordEncoding.EncodeToString(id[:])},
+		{"%24s", fmt.Sprintf("%24s", id.String())},
+		{"%-24s|", fmt.Sprintf("%-24s|", id.String())},
+		{"%z", "%!z(buid.ID=" + id.String() + ")"},
+	} {
+		if s := fmt.Sprintf(tc.format, id); s != tc.expected {
+			t.Fatalf("%s: expect %q got %q", tc.format, tc.expected, s)
+		}
+	}
+}
+
+func TestFormatCrockford(t *testing.T) {
+	var id ID
+	id[15] = 1
+	s := fmt.Sprintf("%o", id)
+	if len(s) != 26 {
+		t.Fatalf("expect 26 characters got %d", len(s))
+	}
+	decoded, err := crockfordEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decoded) != string(id[:]) {
+		t.Fatalf("expect %x got %x", id[:], decoded)
+	}
+}
+
+func TestWriteString(t *testing.T) {
+	p := NewProcess(1)
+	id1, id2 := p.NewID(1, time.Now()), p.NewID(2, time.Now())
+	var sb strings.Builder
+	id1.WriteString(&sb)
+	sb.WriteByte(',')
+	id2.WriteHex(&sb)
+	expected := fmt.Sprintf("%s,%x", id1, id2)
+	if sb.String() != expected {
+		t.Fatalf("expect %s got %s", expected, sb.String())
+	}
+
+	// AllocsPerRun also calls the function once to warm up
+	sb.Reset()
+	sb.Grow(2 * 22)
+	if n := testing.AllocsPerRun(1, func() { id1.WriteString(&sb) }); n != 0 {
+		t.Fatalf("expect 0 allocs got %v", n)
+	}
+}
+
+func BenchmarkWriteString(b *testing.B) {
+	id := NewProcess(1).NewID(2, time.Now())
+	var sb strings.Builder
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sb.Reset()
+		id.WriteString(&sb)
+	}
+}
+
+func BenchmarkFprintfString(b *testing.B) {
+	id := NewProcess(1).NewID(2, time.Now())
+	var sb strings.Builder
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sb.Reset()
+		fmt.Fprintf(&sb, "%s", id)
+	}
+}
+
+func BenchmarkWriteHex(b *testing.B) {
+	id := NewProcess(1).NewID(2, time.Now())
+	var sb strings.Builder
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sb.Reset()
+		id.WriteHex(&sb)
+	}
+}
+
+func BenchmarkFprintfHex(b *testing.B) {
+	id := NewProcess(1).NewID(2, time.Now())
+	var sb strings.Builder
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sb.Reset()
+		fmt.Fprintf(&sb, "%x", id)
+	}
+}
+
+func TestProtoText(t *testing.T) {
+	id := NewProcess(0x0102).NewID(0xff00, time.Now())
+	text := id.MarshalProtoText()
+	if len(text) != 64 || !strings.HasPrefix(text, `\xff\x00`) || !strings.HasSuffix(text, `\x01\x02`) {
+		t.Fatalf("unexpected proto text %s", text)
+	}
+	for _, s := range []string{text, `"` + text + `"`, `'` + text + `'`} {
+		parsed, err := UnmarshalProtoText(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != id {
+			t.Fatalf("expect %v got %v", id, parsed)
+		}
+	}
+
+	// protobuf text format may also use octal escapes and printable characters
+	parsed, err := UnmarshalProtoText(`\000\001AB\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed[1] != 1 || parsed[2] != 'A' || parsed[3] != 'B' {
+		t.Fatalf("unexpected %x", parsed[:])
+	}
+
+	for _, s := range []string{``, `\x00`, `\xzz`, `"` + text} {
+		if _, err := UnmarshalProtoText(s); err == nil {
+			t.Fatalf("expect error for %s", s)
+		}
+	}
+}
+
+func TestHexWithSeparator(t *testing.T) {
+	id := NewProcess(0xabcd).NewID(0x1234, time.Now())
+	h := hex.EncodeToString(id[:])
+	for _, tc := range []struct {
+		sep       byte
+		groupSize int
+		expected  string
+	}{
+		{'-', 2, strings.Join([]string{h[0:4], h[4:8], h[8:12], h[12:16], h[16:20], h[20:24], h[24:28], h[28:32]}, "-")},
+		{':', 1, strings.Join([]string{h[0:2], h[2:4], h[4:6], h[6:8], h[8:10], h[10:12], h[12:14], h[14:16], h[16:18], h[18:20], h[20:22], h[22:24], h[24:26], h[26:28], h[28:30], h[30:32]}, ":")},
+		{'-', 8, h[:16] + "-" + h[16:]},
+		{'-', 5, h[:10] + "-" + h[10:20] + "-" + h[20:30] + "-" + h[30:]},
+		{'-', 0, h},
+		{'-', 16, h},
+	} {
+		s := id.MarshalHexWithSeparator(tc.sep, tc.groupSize)
+		if s != tc.expected {
+			t.Fatalf("expect %s got %s", tc.expected, s)
+		}
+		parsed, err := UnmarshalHexWithSeparator(s, tc.sep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != id {
+			t.Fatalf("expect %v got %v", id, parsed)
+		}
+	}
+
+	uuid := h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+	if parsed, err := UnmarshalHexWithSeparator(uuid, '-'); err != nil || parsed != id {
+		t.Fatalf("expect %v got %v, %v", id, parsed, err)
+	}
+	for _, s := range []string{h[:30], h + "00", "zz" + h[2:], h[:8] + ":" + h[8:]} {
+		if _, err := UnmarshalHexWithSeparator(s, '-'); err == nil {
+			t.Fatalf("expect error for %s", s)
+		}
+	}
+}
+
+func TestEncodeForDisplay(t *testing.T) {
+	var id ID
+	if err := id.UnmarshalText([]byte("0skIcr10rnBGT3wdrHO2")); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		maxLen   int
+		expected string
+	}{
+		{8, "0skIcr10…"},
+		{0, "…"},
+		{-1, "…"},
+		{20, "0skIcr10rnBGT3wdrHO2"},
+		{30, "0skIcr10rnBGT3wdrHO2"},
+	} {
+		if s := id.EncodeForDisplay(tc.maxLen); s != tc.expected {
+			t.Fatalf("expect %s got %s", tc.expected, s)
+		}
+	}
+	for _, tc := range []struct {
+		prefixLen, suffixLen int
+		expected             string
+	}{
+		{4, 4, "0skI…rHO2"},
+		{0, 4, "…rHO2"},
+		{4, 0, "0skI…"},
+		{10, 10, "0skIcr10rnBGT3wdrHO2"},
+	} {
+		if s := id.EncodeForDisplayPrefixSuffix(tc.prefixLen, tc.suffixLen); s != tc.expected {
+			t.Fatalf("expect %s got %s", tc.expected, s)
+		}
+	}
+}
+
+func TestMarshalAmber(t *testing.T) {
+	var id ID
+	if err := id.UnmarshalText([]byte(ToOpenAPIExample())); err != nil {
+		t.Fatal(err)
+	}
+	if s := id.MarshalAmber(); s != ToOpenAPIExample() {
+		t.Fatalf("expect %s got %s", ToOpenAPIExample(), s)
+	}
+	if s, expected := id.MarshalHTMLAttribute(), `"0skIcr10rnBGT3wdrHO2"`; s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+	if s := (ID{}).MarshalHTMLAttribute(); s != `""` {
+		t.Fatalf(`expect "" got %s`, s)
+	}
+}
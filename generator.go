@@ -0,0 +1,19 @@
+package buid
+
+import "time"
+
+// NewIDGenerator returns a closure generating a new BUID of shard at the
+// current time on each call
+func NewIDGenerator(shard uint16, p *Process) func() ID {
+	return func() ID {
+		return p.NewIDNow(shard)
+	}
+}
+
+// NewIDGeneratorWithTime returns a closure generating a new BUID of shard at
+// the time returned by clock on each call
+func NewIDGeneratorWithTime(shard uint16, p *Process, clock func() time.Time) func() ID {
+	return func() ID {
+		return p.NewID(shard, clock())
+	}
+}
@@ -0,0 +1,54 @@
+package buid
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewIDGenerator(t *testing.T) {
+	p := NewProcess(3)
+	gen1 := NewIDGenerator(1, p)
+	gen2 := NewIDGenerator(2, p)
+	var wg sync.WaitGroup
+	ids := make([][]ID, 4)
+	for i := range ids {
+		i := i
+		gen := gen1
+		if i%2 == 1 {
+			gen = gen2
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10000; j++ {
+				ids[i] = append(ids[i], gen())
+			}
+		}()
+	}
+	wg.Wait()
+	m := make(map[ID]bool)
+	for i := range ids {
+		for _, id := range ids[i] {
+			if expected := uint16(i%2 + 1); id.Shard() != expected {
+				t.Fatalf("expect shard %d got %d", expected, id.Shard())
+			}
+			if m[id] {
+				t.Fatal("duplication detected")
+			}
+			m[id] = true
+		}
+	}
+}
+
+func TestNewIDGeneratorWithTime(t *testing.T) {
+	p := NewProcess(3)
+	ts := externalTime(p.t).Add(time.Hour)
+	gen := NewIDGeneratorWithTime(5, p, func() time.Time { return ts })
+	for i := 0; i < 3; i++ {
+		id := gen()
+		if id.Shard() != 5 || !id.Time().Equal(ts) || int(id.Counter()) != i {
+			t.Fatalf("unexpected ID %d: shard %d, time %v, counter %d", i, id.Shard(), id.Time(), id.Counter())
+		}
+	}
+}
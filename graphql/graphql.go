@@ -0,0 +1,70 @@
+// Package graphql provides a graphql-go custom scalar for BUID
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"h12.io/buid"
+)
+
+// BUIDScalar is the GraphQL scalar type of BUID, encoded as a base-62 string
+var BUIDScalar = NewScalar("BUID")
+
+// NewScalar returns a GraphQL scalar type of BUID with a custom name, e.g. to
+// distinguish the IDs of different entities in a schema
+func NewScalar(name string) *graphql.Scalar {
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:         name,
+		Description:  "Bipartite Unique Identifier encoded as a base-62 string",
+		Serialize:    Serialize,
+		ParseValue:   ParseValue,
+		ParseLiteral: ParseLiteral,
+	})
+}
+
+// Serialize coerces a buid.ID or *buid.ID result to its base-62 string, the
+// same as ID.ToGraphQLID
+func Serialize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case buid.ID:
+		return v.String()
+	case *buid.ID:
+		if v == nil {
+			return nil
+		}
+		return v.String()
+	}
+	return nil
+}
+
+// ParseValue coerces a string input variable to a buid.ID, or returns nil if
+// it is invalid
+func ParseValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return parseString(v)
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return parseString(*v)
+	}
+	return nil
+}
+
+// ParseLiteral coerces a string literal in a query to a buid.ID, or returns
+// nil if it is invalid
+func ParseLiteral(valueAST ast.Value) interface{} {
+	if v, ok := valueAST.(*ast.StringValue); ok {
+		return parseString(v.Value)
+	}
+	return nil
+}
+
+func parseString(s string) interface{} {
+	var id buid.ID
+	if err := id.UnmarshalText([]byte(s)); err != nil || id.IsZero() {
+		return nil
+	}
+	return id
+}
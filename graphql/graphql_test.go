@@ -0,0 +1,138 @@
+package graphql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"h12.io/buid"
+)
+
+func testSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"shard": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(BUIDScalar)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return int(p.Args["id"].(buid.ID).Shard()), nil
+					},
+				},
+				"echo": &graphql.Field{
+					Type: BUIDScalar,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: BUIDScalar},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["id"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestQueryLiteral(t *testing.T) {
+	id := buid.NewProcess(1).NewID(42, time.Now())
+	result := graphql.Do(graphql.Params{
+		Schema:        testSchema(t),
+		RequestString: `{ shard(id: "` + id.String() + `") echo(id: "` + id.String() + `") }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatal(result.Errors)
+	}
+	data := result.Data.(map[string]interface{})
+	if data["shard"] != 42 {
+		t.Fatalf("expect 42 got %v", data["shard"])
+	}
+	if data["echo"] != id.String() {
+		t.Fatalf("expect %v got %v", id.String(), data["echo"])
+	}
+}
+
+func TestQueryVariable(t *testing.T) {
+	id := buid.NewProcess(1).NewID(7, time.Now())
+	result := graphql.Do(graphql.Params{
+		Schema:         testSchema(t),
+		RequestString:  `query Q($id: BUID!) { shard(id: $id) }`,
+		VariableValues: map[string]interface{}{"id": id.String()},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatal(result.Errors)
+	}
+	if data := result.Data.(map[string]interface{}); data["shard"] != 7 {
+		t.Fatalf("expect 7 got %v", data["shard"])
+	}
+}
+
+func TestQueryInvalidLiteral(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        testSchema(t),
+		RequestString: `{ shard(id: "not-a-buid") }`,
+	})
+	if len(result.Errors) == 0 {
+		t.Fatal("expect error")
+	}
+}
+
+func TestNewScalar(t *testing.T) {
+	orderID := NewScalar("OrderID")
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"order": &graphql.Field{
+					Type: orderID,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(orderID)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["id"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := buid.NewProcess(1).NewID(3, time.Now())
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query Q($id: OrderID!) { order(id: $id) }`,
+		VariableValues: map[string]interface{}{"id": id.ToGraphQLID()},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatal(result.Errors)
+	}
+	if data := result.Data.(map[string]interface{}); data["order"] != id.ToGraphQLID() {
+		t.Fatalf("expect %v got %v", id.ToGraphQLID(), data["order"])
+	}
+}
+
+func TestCoercion(t *testing.T) {
+	id := buid.NewProcess(1).NewID(3, time.Now())
+	if v := Serialize(id); v != id.ToGraphQLID() {
+		t.Fatalf("expect %v got %v", id.ToGraphQLID(), v)
+	}
+	if v := Serialize(&id); v != id.ToGraphQLID() {
+		t.Fatalf("expect %v got %v", id.ToGraphQLID(), v)
+	}
+	if v := Serialize(1); v != nil {
+		t.Fatalf("expect nil got %v", v)
+	}
+	if v := ParseValue(id.ToGraphQLID()); v != id {
+		t.Fatalf("expect %v got %v", id, v)
+	}
+	if v := ParseValue("!"); v != nil {
+		t.Fatalf("expect nil got %v", v)
+	}
+}
@@ -0,0 +1,56 @@
+package buid
+
+import (
+	"errors"
+	"time"
+)
+
+// Quantize returns a canonical group key for time-series aggregation, with the
+// embedded timestamp truncated to a multiple of resolution and the counter and
+// process zeroed
+//
+// All IDs of the same shard and namespace within a window [k*resolution,
+// (k+1)*resolution) produce the same ID. The resolution must be a second or a
+// whole number of minutes, e.g. time.Minute, time.Hour or 24*time.Hour.
+func (id ID) Quantize(resolution time.Duration) (ID, error) {
+	if resolution != time.Second && (resolution <= 0 || resolution%time.Minute != 0) {
+		return ID{}, errors.New("resolution must be a second or a whole number of minutes")
+	}
+	t := internalTime(id.Time().Truncate(resolution))
+	f := id.Fields()
+	return IDFields{
+		ShardIndex: f.ShardIndex,
+		Namespace:  f.Namespace,
+		Hour:       uint32(t / hourInNano),
+		Minute:     uint8((t % hourInNano) / minuteInNano),
+		Second:     uint8((t % minuteInNano) / secondInNano),
+	}.ID(), nil
+}
+
+// GroupByHour groups ids by the hour of their shard parts in UTC
+func GroupByHour(ids []ID) map[time.Time][]ID {
+	groups := make(map[time.Time][]ID)
+	for _, id := range ids {
+		hour := id.TimeHour()
+		groups[hour] = append(groups[hour], id)
+	}
+	return groups
+}
+
+// GroupByShard groups ids by shard index
+func GroupByShard(ids []ID) map[uint16][]ID {
+	groups := make(map[uint16][]ID)
+	for _, id := range ids {
+		groups[id.Shard()] = append(groups[id.Shard()], id)
+	}
+	return groups
+}
+
+// GroupByProcess groups ids by process
+func GroupByProcess(ids []ID) map[uint16][]ID {
+	groups := make(map[uint16][]ID)
+	for _, id := range ids {
+		groups[id.Process()] = append(groups[id.Process()], id)
+	}
+	return groups
+}
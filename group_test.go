@@ -0,0 +1,126 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuantize(t *testing.T) {
+	p := NewProcess(12)
+	base := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	id := p.NewID(7, base)
+	for _, tc := range []struct {
+		resolution time.Duration
+		expected   time.Time
+	}{
+		{time.Second, time.Date(2100, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{time.Minute, time.Date(2100, 1, 2, 3, 4, 0, 0, time.UTC)},
+		{15 * time.Minute, time.Date(2100, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{time.Hour, time.Date(2100, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{24 * time.Hour, time.Date(2100, 1, 2, 0, 0, 0, 0, time.UTC)},
+	} {
+		q, err := id.Quantize(tc.resolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !q.Time().Equal(tc.expected) || q.Shard() != 7 || q.Counter() != 0 || q.Process() != 0 {
+			t.Fatalf("%v: unexpected ID %v", tc.resolution, q.Verbose())
+		}
+		// another ID in the same window
+		other := NewProcess(13).NewID(7, tc.expected.Add(tc.resolution-time.Nanosecond))
+		if q2, _ := other.Quantize(tc.resolution); q2 != q {
+			t.Fatalf("%v: expect %v got %v", tc.resolution, q.Verbose(), q2.Verbose())
+		}
+		// the next window
+		next := NewProcess(13).NewID(7, tc.expected.Add(tc.resolution))
+		if q3, _ := next.Quantize(tc.resolution); q3 == q {
+			t.Fatalf("%v: expect a different group key", tc.resolution)
+		}
+	}
+}
+
+func TestQuantizeError(t *testing.T) {
+	id := NewProcess(12).NewIDNow(7)
+	for _, resolution := range []time.Duration{0, -time.Minute, time.Millisecond, 2 * time.Second, 90 * time.Second} {
+		if _, err := id.Quantize(resolution); err == nil {
+			t.Fatalf("expect error for %v", resolution)
+		}
+	}
+}
+
+func groupTestIDs() []ID {
+	base := time.Date(2100, 1, 2, 3, 0, 0, 0, time.UTC)
+	var ids []ID
+	for process := uint16(0); process < 3; process++ {
+		p := NewProcess(process)
+		for i := 0; i < 12; i++ {
+			ids = append(ids, p.NewID(uint16(i%4), base.Add(time.Duration(i)*20*time.Minute)))
+		}
+	}
+	return ids
+}
+
+func TestGroupByHour(t *testing.T) {
+	ids := groupTestIDs()
+	groups := GroupByHour(ids)
+	if len(groups) != 4 {
+		t.Fatalf("expect 4 groups got %d", len(groups))
+	}
+	n := 0
+	for hour, group := range groups {
+		if hour.Location() != time.UTC || !hour.Equal(hour.Truncate(time.Hour)) {
+			t.Fatalf("expect a UTC hour got %v", hour)
+		}
+		for _, id := range group {
+			if !id.Time().Truncate(time.Hour).Equal(hour) {
+				t.Fatalf("expect %v in %v", id.Verbose(), hour)
+			}
+		}
+		n += len(group)
+	}
+	if n != len(ids) {
+		t.Fatalf("expect %d IDs got %d", len(ids), n)
+	}
+}
+
+func TestGroupByShardAndProcess(t *testing.T) {
+	ids := groupTestIDs()
+	for _, tc := range []struct {
+		groups map[uint16][]ID
+		field  func(ID) uint16
+		count  int
+	}{
+		{GroupByShard(ids), ID.Shard, 4},
+		{GroupByProcess(ids), ID.Process, 3},
+	} {
+		if len(tc.groups) != tc.count {
+			t.Fatalf("expect %d groups got %d", tc.count, len(tc.groups))
+		}
+		n := 0
+		for k, group := range tc.groups {
+			for _, id := range group {
+				if tc.field(id) != k {
+					t.Fatalf("expect %v in %d", id.Verbose(), k)
+				}
+			}
+			n += len(group)
+		}
+		if n != len(ids) {
+			t.Fatalf("expect %d IDs got %d", len(ids), n)
+		}
+	}
+}
+
+func TestGroupEmpty(t *testing.T) {
+	for _, ids := range [][]ID{nil, {}} {
+		if g := GroupByHour(ids); g == nil || len(g) != 0 {
+			t.Fatalf("expect an empty map got %v", g)
+		}
+		if g := GroupByShard(ids); g == nil || len(g) != 0 {
+			t.Fatalf("expect an empty map got %v", g)
+		}
+		if g := GroupByProcess(ids); g == nil || len(g) != 0 {
+			t.Fatalf("expect an empty map got %v", g)
+		}
+	}
+}
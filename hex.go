@@ -0,0 +1,61 @@
+package buid
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// Hex returns the 32-character lower case hex encoded string, the same as
+// fmt.Sprintf("%x", id)
+func (id ID) Hex() string {
+	var buf [32]byte
+	hex.Encode(buf[:], id[:])
+	return string(buf[:])
+}
+
+// IDFromHex parses a 32-character hex encoded string
+func IDFromHex(s string) (ID, error) {
+	var id ID
+	err := decodeHex(id[:], s, "BUID")
+	return id, err
+}
+
+// Hex returns the 16-character lower case hex encoded string
+func (k Key) Hex() string {
+	var buf [16]byte
+	hex.Encode(buf[:], k[:])
+	return string(buf[:])
+}
+
+// KeyFromHex parses a 16-character hex encoded string
+func KeyFromHex(s string) (Key, error) {
+	var k Key
+	err := decodeHex(k[:], s, "key")
+	return k, err
+}
+
+// Hex returns the 16-character lower case hex encoded string
+func (s Shard) Hex() string {
+	var buf [16]byte
+	hex.Encode(buf[:], s[:])
+	return string(buf[:])
+}
+
+// ShardFromHex parses a 16-character hex encoded string
+func ShardFromHex(str string) (Shard, error) {
+	var s Shard
+	err := decodeHex(s[:], str, "shard")
+	return s, err
+}
+
+// decodeHex decodes s into dst, which must be half as long as s, typ is the
+// name of the decoded type in the error
+func decodeHex(dst []byte, s, typ string) error {
+	if len(s) != len(dst)*2 {
+		return fmt.Errorf("%s hex string must be %d characters, got %d", typ, len(dst)*2, len(s))
+	}
+	if _, err := hex.Decode(dst, []byte(s)); err != nil {
+		return fmt.Errorf("invalid %s hex string %q: %w", typ, s, err)
+	}
+	return nil
+}
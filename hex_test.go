@@ -0,0 +1,90 @@
+package buid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHex(t *testing.T) {
+	var max ID
+	for i := range max {
+		max[i] = 0xff
+	}
+	for _, id := range []ID{{}, max, NewProcess(0xabcd).NewID(0x1234, time.Now())} {
+		s := id.Hex()
+		if expected := fmt.Sprintf("%x", id); s != expected {
+			t.Fatalf("expect %s got %s", expected, s)
+		}
+		if parsed, err := IDFromHex(s); err != nil || parsed != id {
+			t.Fatalf("expect %v got %v, %v", id, parsed, err)
+		}
+		if parsed, err := IDFromHex(strings.ToUpper(s)); err != nil || parsed != id {
+			t.Fatalf("expect %v got %v, %v", id, parsed, err)
+		}
+
+		shard, key := id.Split()
+		if expected := hex.EncodeToString(shard[:]); shard.Hex() != expected {
+			t.Fatalf("expect %s got %s", expected, shard.Hex())
+		}
+		if parsed, err := ShardFromHex(shard.Hex()); err != nil || parsed != shard {
+			t.Fatalf("expect %v got %v, %v", shard, parsed, err)
+		}
+		if expected := hex.EncodeToString(key[:]); key.Hex() != expected {
+			t.Fatalf("expect %s got %s", expected, key.Hex())
+		}
+		if parsed, err := KeyFromHex(key.Hex()); err != nil || parsed != key {
+			t.Fatalf("expect %v got %v, %v", key, parsed, err)
+		}
+	}
+	if s := max.Hex(); s != strings.Repeat("f", 32) {
+		t.Fatalf("expect all f got %s", s)
+	}
+}
+
+func TestHexError(t *testing.T) {
+	for _, s := range []string{"", strings.Repeat("0", 31), strings.Repeat("0", 33), strings.Repeat("0", 31) + "g"} {
+		if _, err := IDFromHex(s); err == nil {
+			t.Fatalf("expect error for %q", s)
+		}
+	}
+	for _, s := range []string{strings.Repeat("0", 15), strings.Repeat("0", 32), strings.Repeat("0", 15) + "-"} {
+		if _, err := KeyFromHex(s); err == nil {
+			t.Fatalf("expect error for %q", s)
+		}
+		if _, err := ShardFromHex(s); err == nil {
+			t.Fatalf("expect error for %q", s)
+		}
+	}
+}
+
+var hexSink string
+
+func BenchmarkHex(b *testing.B) {
+	id := NewProcess(1).NewID(2, time.Now())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hexSink = id.Hex()
+	}
+}
+
+func BenchmarkHexEncodeToString(b *testing.B) {
+	id := NewProcess(1).NewID(2, time.Now())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hexSink = hex.EncodeToString(id[:])
+	}
+}
+
+func BenchmarkIDFromHex(b *testing.B) {
+	s := NewProcess(1).NewID(2, time.Now()).Hex()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		IDFromHex(s)
+	}
+}
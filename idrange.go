@@ -0,0 +1,82 @@
+package buid
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// IDRange is an inclusive range of IDs [Min, Max]
+type IDRange struct {
+	Min ID
+	Max ID
+}
+
+// Contains returns whether id is within the range, bounds included
+func (r IDRange) Contains(id ID) bool {
+	return !id.Before(r.Min) && !id.After(r.Max)
+}
+
+// Overlaps returns whether r and other have at least one ID in common
+func (r IDRange) Overlaps(other IDRange) bool {
+	return !r.Max.Before(other.Min) && !other.Max.Before(r.Min)
+}
+
+// Scan calls fn for each ID within the range in ids, which must be sorted in
+// ascending order, until fn returns false
+//
+// It finds the first ID with a binary search and stops at the first ID after
+// Max.
+func (r IDRange) Scan(ids []ID, fn func(ID) bool) {
+	i := sort.Search(len(ids), func(i int) bool { return !ids[i].Before(r.Min) })
+	for ; i < len(ids) && !ids[i].After(r.Max); i++ {
+		if !fn(ids[i]) {
+			return
+		}
+	}
+}
+
+type idRangeJSON struct {
+	Lo ID `json:"lo"`
+	Hi ID `json:"hi"`
+}
+
+// MarshalJSON implements json.Marshaler, e.g. {"lo":"...","hi":"..."}
+func (r IDRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(idRangeJSON{Lo: r.Min, Hi: r.Max})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (r *IDRange) UnmarshalJSON(data []byte) error {
+	var v idRangeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	r.Min, r.Max = v.Lo, v.Hi
+	return nil
+}
+
+// ToBase64Token returns the base64url encoded 32 bytes of Min followed by Max,
+// for use as an opaque pagination cursor
+func (r IDRange) ToBase64Token() string {
+	var buf [32]byte
+	copy(buf[:16], r.Min[:])
+	copy(buf[16:], r.Max[:])
+	return base64.RawURLEncoding.EncodeToString(buf[:])
+}
+
+// IDRangeFromBase64Token parses a token returned by ToBase64Token
+func IDRangeFromBase64Token(token string) (IDRange, error) {
+	var r IDRange
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return r, err
+	}
+	if len(buf) != 32 {
+		return r, errors.New("ID range token must be 256 bit")
+	}
+	copy(r.Min[:], buf[:16])
+	copy(r.Max[:], buf[16:])
+	return r, nil
+}
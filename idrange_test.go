@@ -0,0 +1,116 @@
+package buid
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestIDRangeJSON(t *testing.T) {
+	p := NewProcess(1)
+	r1 := IDRange{Min: p.NewID(2, time.Now()), Max: p.NewID(2, time.Now().Add(time.Hour))}
+	buf, err := json.Marshal(r1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"lo":"` + r1.Min.String() + `","hi":"` + r1.Max.String() + `"}`
+	if string(buf) != expected {
+		t.Fatalf("expect %s got %s", expected, buf)
+	}
+	var r2 IDRange
+	if err := json.Unmarshal(buf, &r2); err != nil {
+		t.Fatal(err)
+	}
+	if r1 != r2 {
+		t.Fatalf("expect %v got %v", r1, r2)
+	}
+	if err := json.Unmarshal([]byte(`{"lo":"!"}`), &r2); err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestIDRangeBase64Token(t *testing.T) {
+	p := NewProcess(1)
+	r1 := IDRange{Min: p.NewID(2, time.Now()), Max: p.NewID(2, time.Now().Add(time.Hour))}
+	token := r1.ToBase64Token()
+	if len(token) != 43 {
+		t.Fatalf("expect 43 characters got %d", len(token))
+	}
+	r2, err := IDRangeFromBase64Token(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r1 != r2 {
+		t.Fatalf("expect %v got %v", r1, r2)
+	}
+	for _, token := range []string{"", token[:42], token + "AA", "*" + token[1:]} {
+		if _, err := IDRangeFromBase64Token(token); err == nil {
+			t.Fatalf("expect error for %s", token)
+		}
+	}
+}
+
+func TestIDRangeContains(t *testing.T) {
+	ids := NewProcess(1).NewIDs(2, time.Now(), 4)
+	r := IDRange{Min: ids[1], Max: ids[2]}
+	for i, expected := range []bool{false, true, true, false} {
+		if actual := r.Contains(ids[i]); actual != expected {
+			t.Fatalf("expect %v got %v for %d", expected, actual, i)
+		}
+	}
+	if !(IDRange{Min: ids[1], Max: ids[1]}).Contains(ids[1]) {
+		t.Fatal("expect a single ID range to contain its ID")
+	}
+}
+
+func TestIDRangeOverlaps(t *testing.T) {
+	ids := NewProcess(1).NewIDs(2, time.Now(), 6)
+	for _, c := range []struct {
+		a, b     IDRange
+		expected bool
+	}{
+		{IDRange{ids[0], ids[2]}, IDRange{ids[1], ids[3]}, true},
+		{IDRange{ids[0], ids[2]}, IDRange{ids[2], ids[3]}, true},
+		{IDRange{ids[0], ids[5]}, IDRange{ids[2], ids[3]}, true},
+		{IDRange{ids[0], ids[1]}, IDRange{ids[2], ids[3]}, false},
+		{IDRange{ids[4], ids[5]}, IDRange{ids[2], ids[3]}, false},
+	} {
+		if actual := c.a.Overlaps(c.b); actual != c.expected {
+			t.Fatalf("expect %v got %v for %v and %v", c.expected, actual, c.a, c.b)
+		}
+		if actual := c.b.Overlaps(c.a); actual != c.expected {
+			t.Fatalf("expect %v got %v for %v and %v", c.expected, actual, c.b, c.a)
+		}
+	}
+}
+
+func TestIDRangeScan(t *testing.T) {
+	ids := NewProcess(1).NewIDs(2, time.Now(), 10)
+	sort.Sort(ByTime(ids))
+	r := IDRange{Min: ids[3], Max: ids[6]}
+	var scanned []ID
+	r.Scan(ids, func(id ID) bool {
+		scanned = append(scanned, id)
+		return true
+	})
+	if !reflect.DeepEqual(scanned, ids[3:7]) {
+		t.Fatalf("expect %v got %v", ids[3:7], scanned)
+	}
+
+	scanned = nil
+	r.Scan(ids, func(id ID) bool {
+		scanned = append(scanned, id)
+		return len(scanned) < 2
+	})
+	if !reflect.DeepEqual(scanned, ids[3:5]) {
+		t.Fatalf("expect %v got %v", ids[3:5], scanned)
+	}
+
+	r = IDRange{Min: MaxIDForShard(3, time.Now()), Max: MaxIDForShard(4, time.Now())}
+	r.Scan(ids, func(id ID) bool {
+		t.Fatalf("expect no ID scanned got %v", id)
+		return true
+	})
+}
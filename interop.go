@@ -0,0 +1,124 @@
+package buid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ToElasticsearchID returns the base-62 string as an Elasticsearch document _id
+//
+// Elasticsearch routes a document to a primary shard by hashing its _id, so
+// IDs of the same BUID shard are spread over all Elasticsearch shards. To keep
+// them together, index with the routing parameter set to id.Shard().
+func (id ID) ToElasticsearchID() string {
+	return id.String()
+}
+
+// ElasticsearchMapping returns the Elasticsearch field mapping for a BUID
+// stored as a keyword
+func ElasticsearchMapping() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "keyword",
+		"index":      true,
+		"doc_values": true,
+	}
+}
+
+// ToGraphQLID returns the base-62 string as a GraphQL ID, which the
+// specification serializes as a String
+//
+// For graphql-go, the graphql sub-package provides a BUID scalar type.
+func (id ID) ToGraphQLID() string {
+	return id.String()
+}
+
+// ToFirestoreDocumentID returns the base-62 string as a Firestore document ID
+//
+// The base-62 alphabet contains neither "/" nor ".", so the string is a valid
+// document ID as is. A zero ID encodes to an empty string, which is not.
+func (id ID) ToFirestoreDocumentID() string {
+	return id.String()
+}
+
+// IDFromFirestoreDocumentID parses a Firestore document ID returned by
+// ToFirestoreDocumentID
+func IDFromFirestoreDocumentID(s string) (ID, error) {
+	var id ID
+	if s == "" {
+		return id, errors.New("empty Firestore document ID")
+	}
+	err := id.UnmarshalText([]byte(s))
+	return id, err
+}
+
+// FirestoreKeyPath returns the document path of id in collection
+//
+// Firestore limits the size of a document name (6 KiB at the time of
+// writing), which includes the full path; a BUID adds at most 23 bytes to the
+// collection path.
+func FirestoreKeyPath(collection string, id ID) string {
+	return collection + "/" + id.ToFirestoreDocumentID()
+}
+
+// ToContentAddressableKey returns the hex encoded SHA-256 of the ID bytes for
+// use as a key in a content-addressable storage (CAS)
+func (id ID) ToContentAddressableKey() string {
+	sum := sha256.Sum256(id[:])
+	return hex.EncodeToString(sum[:])
+}
+
+// IDFromContentAddressableKey looks up the ID of a CAS key in ids, a map from
+// CAS keys returned by ToContentAddressableKey to IDs
+//
+// The SHA-256 cannot be reversed, so the caller must keep the map.
+func IDFromContentAddressableKey(key string, ids map[string]ID) (ID, bool, error) {
+	if len(key) != hex.EncodedLen(sha256.Size) {
+		return ID{}, false, errors.New("CAS key must be a hex encoded SHA-256")
+	}
+	if _, err := hex.DecodeString(key); err != nil {
+		return ID{}, false, err
+	}
+	id, ok := ids[strings.ToLower(key)]
+	return id, ok, nil
+}
+
+// ToDockerContainerID returns the hex encoded SHA-256 of the ID bytes as a
+// 64-character Docker container ID, for deterministic container naming
+func (id ID) ToDockerContainerID() string {
+	return id.ToContentAddressableKey()
+}
+
+// IDFromDockerContainerID looks up the ID of a Docker container ID in ids, a
+// map from container IDs returned by ToDockerContainerID to IDs
+//
+// Like the Docker CLI, s may be the full 64-character ID or the 12-character
+// short form, which is an error if it matches more than one ID.
+func IDFromDockerContainerID(s string, ids map[string]ID) (ID, bool, error) {
+	const shortLen = 12
+	switch len(s) {
+	case hex.EncodedLen(sha256.Size):
+		return IDFromContentAddressableKey(s, ids)
+	case shortLen:
+	default:
+		return ID{}, false, errors.New("Docker container ID must be 64 or 12 hex characters")
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return ID{}, false, err
+	}
+	s = strings.ToLower(s)
+	var (
+		id    ID
+		found bool
+	)
+	for containerID, v := range ids {
+		if strings.HasPrefix(containerID, s) {
+			if found {
+				return ID{}, false, errors.New("ambiguous short Docker container ID " + s)
+			}
+			id, found = v, true
+		}
+	}
+	return id, found, nil
+}
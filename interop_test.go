@@ -0,0 +1,149 @@
+package buid
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestElasticsearchID(t *testing.T) {
+	id := NewProcess(1).NewID(2, time.Now())
+	esID := id.ToElasticsearchID()
+	if esID != id.String() {
+		t.Fatalf("expect %v got %v", id.String(), esID)
+	}
+	if len(esID) > 512 {
+		t.Fatalf("expect at most 512 bytes got %d", len(esID))
+	}
+}
+
+func TestElasticsearchMapping(t *testing.T) {
+	buf, err := json.Marshal(ElasticsearchMapping())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"doc_values":true,"index":true,"type":"keyword"}`
+	if string(buf) != expected {
+		t.Fatalf("expect %s got %s", expected, buf)
+	}
+}
+
+func TestGraphQLID(t *testing.T) {
+	id := NewProcess(1).NewID(2, time.Now())
+	if s := id.ToGraphQLID(); s != id.String() {
+		t.Fatalf("expect %v got %v", id.String(), s)
+	}
+}
+
+func TestFirestoreDocumentID(t *testing.T) {
+	id := NewProcess(1).NewID(2, time.Now())
+	docID := id.ToFirestoreDocumentID()
+	if strings.ContainsAny(docID, "/.") {
+		t.Fatalf("invalid document ID %s", docID)
+	}
+	parsed, err := IDFromFirestoreDocumentID(docID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != id {
+		t.Fatalf("expect %v got %v", id, parsed)
+	}
+	if _, err := IDFromFirestoreDocumentID(""); err == nil {
+		t.Fatal("expect error")
+	}
+	if _, err := IDFromFirestoreDocumentID("a/b"); err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestFirestoreKeyPath(t *testing.T) {
+	id := NewProcess(1).NewID(2, time.Now())
+	expected := "messages/" + id.String()
+	if path := FirestoreKeyPath("messages", id); path != expected {
+		t.Fatalf("expect %v got %v", expected, path)
+	}
+}
+
+func TestContentAddressableKey(t *testing.T) {
+	p := NewProcess(1)
+	ids := make(map[string]ID)
+	var keys []string
+	for i := 0; i < 10; i++ {
+		id := p.NewID(2, time.Now())
+		key := id.ToContentAddressableKey()
+		if len(key) != 64 {
+			t.Fatalf("expect 64 characters got %d", len(key))
+		}
+		ids[key] = id
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		id, ok, err := IDFromContentAddressableKey(strings.ToUpper(key), ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || id != ids[key] {
+			t.Fatalf("expect %v got %v", ids[key], id)
+		}
+	}
+	unknown := p.NewID(2, time.Now()).ToContentAddressableKey()
+	if _, ok, err := IDFromContentAddressableKey(unknown, ids); err != nil || ok {
+		t.Fatalf("expect not found got %v, %v", ok, err)
+	}
+	if _, _, err := IDFromContentAddressableKey("abc", ids); err == nil {
+		t.Fatal("expect error")
+	}
+	if _, _, err := IDFromContentAddressableKey(strings.Repeat("x", 64), ids); err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestDockerContainerID(t *testing.T) {
+	p := NewProcess(1)
+	ids := make(map[string]ID)
+	var expected []ID
+	for i := 0; i < 3; i++ {
+		id := p.NewID(2, time.Now())
+		containerID := id.ToDockerContainerID()
+		if len(containerID) != 64 || strings.ToLower(containerID) != containerID {
+			t.Fatalf("expect 64 lower case hex characters got %s", containerID)
+		}
+		ids[containerID] = id
+		expected = append(expected, id)
+	}
+	for _, id := range expected {
+		containerID := id.ToDockerContainerID()
+		for _, s := range []string{containerID, containerID[:12], strings.ToUpper(containerID[:12])} {
+			got, ok, err := IDFromDockerContainerID(s, ids)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok || got != id {
+				t.Fatalf("expect %v got %v, %v", id, got, ok)
+			}
+		}
+	}
+
+	unknown := p.NewID(2, time.Now()).ToDockerContainerID()
+	for _, s := range []string{unknown, unknown[:12]} {
+		if _, ok, err := IDFromDockerContainerID(s, ids); err != nil || ok {
+			t.Fatalf("expect not found got %v, %v", ok, err)
+		}
+	}
+	for _, s := range []string{"", "abc", strings.Repeat("x", 12), strings.Repeat("x", 64)} {
+		if _, _, err := IDFromDockerContainerID(s, ids); err == nil {
+			t.Fatalf("expect error for %q", s)
+		}
+	}
+}
+
+func TestDockerContainerIDAmbiguous(t *testing.T) {
+	ids := map[string]ID{
+		"0123456789ab" + strings.Repeat("0", 52): {1},
+		"0123456789ab" + strings.Repeat("1", 52): {2},
+	}
+	if _, _, err := IDFromDockerContainerID("0123456789ab", ids); err == nil {
+		t.Fatal("expect error")
+	}
+}
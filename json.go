@@ -0,0 +1,71 @@
+package buid
+
+import "encoding/json"
+
+// MarshalJSON implements json.Marshaler with the base-62 encoded string
+func (id ID) MarshalJSON() ([]byte, error) {
+	text, _ := id.MarshalText()
+	return quote(text), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, an empty string resets to zero
+func (id *ID) UnmarshalJSON(data []byte) error {
+	text, err := unquote(data)
+	if err != nil || text == nil {
+		return err
+	}
+	*id = ID{}
+	return id.UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler with the base-62 encoded string
+func (k Key) MarshalJSON() ([]byte, error) {
+	text, _ := k.MarshalText()
+	return quote(text), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, an empty string resets to zero
+func (k *Key) UnmarshalJSON(data []byte) error {
+	text, err := unquote(data)
+	if err != nil || text == nil {
+		return err
+	}
+	*k = Key{}
+	return k.UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler with the base-62 encoded string
+func (s Shard) MarshalJSON() ([]byte, error) {
+	text, _ := s.MarshalText()
+	return quote(text), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, an empty string resets to zero
+func (s *Shard) UnmarshalJSON(data []byte) error {
+	text, err := unquote(data)
+	if err != nil || text == nil {
+		return err
+	}
+	*s = Shard{}
+	return s.UnmarshalText(text)
+}
+
+// quote quotes base-62 text, which never needs escaping
+func quote(text []byte) []byte {
+	buf := make([]byte, 0, len(text)+2)
+	buf = append(buf, '"')
+	buf = append(buf, text...)
+	return append(buf, '"')
+}
+
+// unquote returns the text of a JSON string, or nil for JSON null
+func unquote(data []byte) ([]byte, error) {
+	if string(data) == "null" {
+		return nil, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
@@ -0,0 +1,79 @@
+package buid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSON(t *testing.T) {
+	type message struct {
+		ID    ID    `json:"id"`
+		Shard Shard `json:"shard"`
+		Key   Key   `json:"key"`
+	}
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, key := id.Split()
+	m1 := message{ID: id, Shard: shard, Key: key}
+	buf1, err := json.Marshal(m1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"id":"` + id.String() + `","shard":"` + shard.String() + `","key":"` + key.String() + `"}`
+	if string(buf1) != expected {
+		t.Fatalf("expect %s got %s", expected, buf1)
+	}
+	var m2 message
+	if err := json.Unmarshal(buf1, &m2); err != nil {
+		t.Fatal(err)
+	}
+	if m1 != m2 {
+		t.Fatalf("expect %v got %v", m1, m2)
+	}
+	buf2, err := json.Marshal(m2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf1) != string(buf2) {
+		t.Fatalf("expect %s got %s", buf1, buf2)
+	}
+}
+
+func TestJSONZero(t *testing.T) {
+	var id ID
+	buf, err := json.Marshal(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != `""` {
+		t.Fatalf(`expect "" got %s`, buf)
+	}
+	id = NewProcess(2).NewID(1, time.Now())
+	if err := json.Unmarshal(buf, &id); err != nil {
+		t.Fatal(err)
+	}
+	if !id.IsZero() {
+		t.Fatal("expect zero ID")
+	}
+	if err := json.Unmarshal([]byte("null"), &id); err != nil || !id.IsZero() {
+		t.Fatalf("expect zero ID got %v, %v", id, err)
+	}
+}
+
+func TestJSONError(t *testing.T) {
+	var id ID
+	var key Key
+	var shard Shard
+	for _, data := range []string{`1`, `"!"`, `"ff"`} {
+		if err := json.Unmarshal([]byte(data), &id); err == nil {
+			t.Fatalf("expect error for %s", data)
+		}
+	}
+	longText, _ := NewProcess(2).NewID(1, time.Now()).MarshalJSON()
+	if err := json.Unmarshal(longText, &key); err == nil {
+		t.Fatal("expect error")
+	}
+	if err := json.Unmarshal(longText, &shard); err == nil {
+		t.Fatal("expect error")
+	}
+}
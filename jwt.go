@@ -0,0 +1,35 @@
+package buid
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// MarshalJWT returns the unpadded base64url encoded raw bytes for a JWT claim
+// value, e.g. the "sub" claim
+func (id ID) MarshalJWT() string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// IDFromJWTClaim reads the ID encoded by MarshalJWT from the claim of claimKey
+// in a decoded JWT claim set
+func IDFromJWTClaim(claims map[string]interface{}, claimKey string) (ID, error) {
+	var id ID
+	v, ok := claims[claimKey]
+	if !ok {
+		return id, fmt.Errorf("JWT claim %q not found", claimKey)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return id, fmt.Errorf("JWT claim %q must be a string, got %T", claimKey, v)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return id, fmt.Errorf("JWT claim %q: %w", claimKey, err)
+	}
+	if len(data) != len(id) {
+		return id, fmt.Errorf("JWT claim %q: BUID length must be 128 bit", claimKey)
+	}
+	copy(id[:], data)
+	return id, nil
+}
@@ -0,0 +1,44 @@
+package buid
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJWT(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	s := id.MarshalJWT()
+	if len(s) != 22 {
+		t.Fatalf("expect 22 characters got %d", len(s))
+	}
+
+	// a claim set as decoded from the JSON payload of a JWT
+	payload, _ := json.Marshal(map[string]interface{}{"sub": s, "exp": 1})
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	id2, err := IDFromJWTClaim(claims, "sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+}
+
+func TestIDFromJWTClaimError(t *testing.T) {
+	claims := map[string]interface{}{
+		"exp":   float64(1),
+		"pad":   base64.URLEncoding.EncodeToString(make([]byte, 16)),
+		"short": base64.RawURLEncoding.EncodeToString(make([]byte, 8)),
+		"std":   "+/+/+/+/+/+/+/+/+/+/+w",
+	}
+	for _, key := range []string{"sub", "exp", "pad", "short", "std"} {
+		if _, err := IDFromJWTClaim(claims, key); err == nil {
+			t.Fatalf("expect error for %s", key)
+		}
+	}
+}
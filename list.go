@@ -0,0 +1,53 @@
+package buid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseIDList parses a list of ID strings separated by sep, e.g. the value of
+// a query parameter like ?ids=abc123,def456
+//
+// Whitespace around each token is trimmed. An empty input returns an empty
+// list, and all invalid tokens are reported together in the returned error.
+func ParseIDList(s string, sep string) ([]ID, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	tokens := strings.Split(s, sep)
+	ids := make([]ID, 0, len(tokens))
+	var errs []error
+	for i, token := range tokens {
+		token = strings.TrimSpace(token)
+		id, err := ParseID(token)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid BUID %q at %d: %w", token, i, err))
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return ids, nil
+}
+
+// FormatIDList formats ids as a list of ID strings separated by sep
+//
+// Zero IDs are skipped, because their string is empty and cannot be parsed by
+// ParseIDList.
+func FormatIDList(ids []ID, sep string) string {
+	var sb strings.Builder
+	sb.Grow(len(ids) * (22 + len(sep)))
+	for _, id := range ids {
+		if id.IsZero() {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(sep)
+		}
+		id.WriteString(&sb)
+	}
+	return sb.String()
+}
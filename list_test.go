@@ -0,0 +1,87 @@
+package buid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIDList(t *testing.T) {
+	p := NewProcess(2)
+	id1 := p.NewID(1, time.Now())
+	id2 := p.NewID(2, time.Now())
+
+	if ids, err := ParseIDList("", ","); err != nil || len(ids) != 0 {
+		t.Fatalf("expect empty list got %v, %v", ids, err)
+	}
+	if ids, err := ParseIDList(" ", ","); err != nil || len(ids) != 0 {
+		t.Fatalf("expect empty list got %v, %v", ids, err)
+	}
+
+	ids, err := ParseIDList(id1.String(), ",")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != id1 {
+		t.Fatalf("expect [%v] got %v", id1, ids)
+	}
+
+	ids, err = ParseIDList(" "+id1.String()+" | "+id2.String()+" ", "|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != id1 || ids[1] != id2 {
+		t.Fatalf("expect [%v %v] got %v", id1, id2, ids)
+	}
+}
+
+func TestParseIDListError(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	if _, err := ParseIDList("!,ff", ","); err == nil {
+		t.Fatal("expect error")
+	} else if msg := err.Error(); !strings.Contains(msg, `"!"`) || !strings.Contains(msg, `"ff"`) {
+		t.Fatalf("expect both invalid tokens in %q", msg)
+	}
+	ids, err := ParseIDList(id.String()+",bad!,,"+id.String(), ",")
+	if err == nil {
+		t.Fatal("expect error")
+	}
+	if ids != nil {
+		t.Fatalf("expect nil got %v", ids)
+	}
+	if msg := err.Error(); !strings.Contains(msg, `"bad!"`) || !strings.Contains(msg, `""`) || strings.Contains(msg, id.String()) {
+		t.Fatalf("unexpected error %q", msg)
+	}
+}
+
+func TestFormatIDList(t *testing.T) {
+	p := NewProcess(2)
+	ids := []ID{p.NewID(1, time.Now()), p.NewID(2, time.Now())}
+	s := FormatIDList(ids, ",")
+	if expected := ids[0].String() + "," + ids[1].String(); s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+	parsed, err := ParseIDList(s, ",")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 2 || parsed[0] != ids[0] || parsed[1] != ids[1] {
+		t.Fatalf("expect %v got %v", ids, parsed)
+	}
+	if s := FormatIDList(nil, ","); s != "" {
+		t.Fatalf("expect empty got %s", s)
+	}
+
+	// zero IDs are skipped
+	s = FormatIDList([]ID{{}, ids[0], {}, ids[1], {}}, ",")
+	parsed, err = ParseIDList(s, ",")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 2 || parsed[0] != ids[0] || parsed[1] != ids[1] {
+		t.Fatalf("expect %v got %v", ids, parsed)
+	}
+	if s := FormatIDList([]ID{{}}, ","); s != "" {
+		t.Fatalf("expect empty got %s", s)
+	}
+}
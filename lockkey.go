@@ -0,0 +1,24 @@
+package buid
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LockKey returns a distributed lock key of id within scope
+//
+// The key is "scope:id", with scope escaped to keep the key URL-safe.
+func LockKey(id ID, scope string) string {
+	return url.PathEscape(scope) + ":" + id.String()
+}
+
+// TimedLockKey returns a distributed lock key of id within scope and the time
+// window of windowSize that the ID's timestamp falls in
+//
+// The key is "scope:id:window", where window is the start of the time window
+// in Unix nanoseconds.
+func TimedLockKey(id ID, scope string, windowSize time.Duration) string {
+	window := id.Time().Truncate(windowSize).UnixNano()
+	return LockKey(id, scope) + ":" + strconv.FormatInt(window, 10)
+}
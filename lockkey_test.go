@@ -0,0 +1,36 @@
+package buid
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLockKey(t *testing.T) {
+	id := NewProcess(1).NewID(2, time.Now())
+	expected := "orders:" + id.String()
+	if key := LockKey(id, "orders"); key != expected {
+		t.Fatalf("expect %s got %s", expected, key)
+	}
+	key := LockKey(id, "a b/c")
+	if !regexp.MustCompile(`^[0-9A-Za-z%:._~-]+$`).MatchString(key) {
+		t.Fatalf("expect URL-safe key got %s", key)
+	}
+}
+
+func TestTimedLockKey(t *testing.T) {
+	p := NewProcess(1)
+	ts := time.Now().UTC().Truncate(time.Minute)
+	id := p.NewID(2, ts.Add(30*time.Second))
+	expected := "orders:" + id.String() + ":" + strconv.FormatInt(ts.UnixNano(), 10)
+	if key := TimedLockKey(id, "orders", time.Minute); key != expected {
+		t.Fatalf("expect %s got %s", expected, key)
+	}
+	if TimedLockKey(id, "orders", time.Minute) != TimedLockKey(id, "orders", time.Minute) {
+		t.Fatal("expect a pure function")
+	}
+	if TimedLockKey(id, "orders", time.Minute) == TimedLockKey(id, "orders", time.Second) {
+		t.Fatal("expect different windows to produce different keys")
+	}
+}
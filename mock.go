@@ -0,0 +1,57 @@
+package buid
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// IDGenerator generates BUIDs, it is implemented by *Process, *ProcessPool and
+// *MockProcess
+type IDGenerator interface {
+	NewID(shard uint16, t time.Time) ID
+}
+
+var (
+	_ IDGenerator = (*Process)(nil)
+	_ IDGenerator = (*ProcessPool)(nil)
+	_ IDGenerator = (*MockProcess)(nil)
+)
+
+// MockProcess is an IDGenerator for deterministic tests, which returns the
+// pre-loaded IDs in order and records every call
+type MockProcess struct {
+	// IDs to return, it panics when they are exhausted
+	IDs []ID
+	// Calls records the arguments of each call
+	Calls []MockCall
+
+	mu sync.Mutex
+}
+
+// MockCall is a recorded call of MockProcess
+type MockCall struct {
+	Shard uint16
+	Time  time.Time
+}
+
+// NewMockProcess returns a MockProcess returning ids
+func NewMockProcess(ids ...ID) *MockProcess {
+	return &MockProcess{IDs: ids}
+}
+
+// NewID records the call and returns the next pre-loaded ID
+func (m *MockProcess) NewID(shard uint16, t time.Time) ID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.Calls) >= len(m.IDs) {
+		panic(fmt.Sprintf("buid: MockProcess exhausted after %d IDs", len(m.IDs)))
+	}
+	m.Calls = append(m.Calls, MockCall{Shard: shard, Time: t})
+	return m.IDs[len(m.Calls)-1]
+}
+
+// NewIDNow calls NewID with the current time
+func (m *MockProcess) NewIDNow(shard uint16) ID {
+	return m.NewID(shard, time.Now())
+}
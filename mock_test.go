@@ -0,0 +1,47 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func createOrder(g IDGenerator, userShard uint16, t time.Time) ID {
+	return g.NewID(userShard, t)
+}
+
+func TestMockProcess(t *testing.T) {
+	p := NewProcess(2)
+	ids := []ID{p.NewID(3, time.Now()), p.NewID(5, time.Now())}
+	m := NewMockProcess(ids...)
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	if id := createOrder(m, 3, ts); id != ids[0] {
+		t.Fatalf("expect %v got %v", ids[0], id)
+	}
+	if id := m.NewIDNow(5); id != ids[1] {
+		t.Fatalf("expect %v got %v", ids[1], id)
+	}
+	if len(m.Calls) != 2 {
+		t.Fatalf("expect 2 calls got %d", len(m.Calls))
+	}
+	if c := m.Calls[0]; c.Shard != 3 || !c.Time.Equal(ts) {
+		t.Fatalf("unexpected call %+v", c)
+	}
+	if c := m.Calls[1]; c.Shard != 5 || c.Time.IsZero() {
+		t.Fatalf("unexpected call %+v", c)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expect panic")
+		}
+	}()
+	m.NewIDNow(1)
+}
+
+func TestProcessAsIDGenerator(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	if id := createOrder(NewProcess(2), 3, ts); id.Shard() != 3 || !id.Time().Equal(ts) {
+		t.Fatalf("unexpected ID %v", id.Verbose())
+	}
+}
@@ -0,0 +1,50 @@
+package buid
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ToMongoObjectID returns a best-effort mapping of the ID to a 12-byte MongoDB
+// ObjectID: Unix seconds (4 bytes), a hash of the process (3 bytes), the shard
+// index (3 bytes) and the counter (2 bytes)
+//
+// The sub-second time, the namespace and the process are lost, so distinct IDs
+// may map to the same ObjectID. Do not rely on the result for uniqueness.
+func (id ID) ToMongoObjectID() primitive.ObjectID {
+	var oid primitive.ObjectID
+	binary.BigEndian.PutUint32(oid[0:4], uint32(id.Time().Unix()))
+	h := mongoProcessHash(id.Process())
+	oid[4], oid[5], oid[6] = byte(h>>16), byte(h>>8), byte(h)
+	shard := id.Shard()
+	oid[7], oid[8], oid[9] = 0, byte(shard>>8), byte(shard)
+	binary.BigEndian.PutUint16(oid[10:12], id.Counter())
+	return oid
+}
+
+// IDFromMongoObjectID returns the ID mapped by ToMongoObjectID with the
+// nanoseconds set to zero
+//
+// The process hash cannot be reversed, so the process ID is passed in.
+func IDFromMongoObjectID(oid primitive.ObjectID, processID uint16) ID {
+	t := time.Unix(int64(binary.BigEndian.Uint32(oid[0:4])), 0)
+	p := &Process{id: processID}
+	var id ID
+	p.makeID(
+		&id,
+		binary.BigEndian.Uint16(oid[8:10]),
+		internalTime(t),
+		binary.BigEndian.Uint16(oid[10:12])&maxCounter,
+	)
+	return id
+}
+
+// mongoProcessHash returns the 24-bit FNV-32a hash of a process ID
+func mongoProcessHash(process uint16) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte{byte(process >> 8), byte(process)})
+	return h.Sum32() & 0xffffff
+}
@@ -0,0 +1,51 @@
+package buid
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestMongoObjectID(t *testing.T) {
+	p := NewProcess(0x1234)
+	ts := time.Now().Add(time.Hour).Truncate(time.Second)
+	first := p.NewID(0xabcd, ts)
+	id := p.NewID(0xabcd, ts) // counter 1
+
+	oid := id.ToMongoObjectID()
+	if !oid.Timestamp().Equal(ts) {
+		t.Fatalf("expect %v got %v", ts, oid.Timestamp())
+	}
+	if s := binary.BigEndian.Uint16(oid[8:10]); s != 0xabcd {
+		t.Fatalf("expect %x got %x", 0xabcd, s)
+	}
+	if c := binary.BigEndian.Uint16(oid[10:12]); c != 1 {
+		t.Fatalf("expect 1 got %d", c)
+	}
+
+	id2 := IDFromMongoObjectID(oid, 0x1234)
+	if id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+	if id2.ToMongoObjectID() != oid {
+		t.Fatalf("expect %v got %v", oid, id2.ToMongoObjectID())
+	}
+
+	// sub-second time is lost
+	id3 := p.NewID(0xabcd, ts.Add(time.Millisecond))
+	if id3.ToMongoObjectID() != first.ToMongoObjectID() {
+		t.Fatal("expect the same ObjectID within a second")
+	}
+	if id4 := IDFromMongoObjectID(id3.ToMongoObjectID(), 0x1234); !id4.Time().Equal(ts) {
+		t.Fatalf("expect %v got %v", ts, id4.Time())
+	}
+}
+
+func TestMongoProcessHash(t *testing.T) {
+	if mongoProcessHash(1) == mongoProcessHash(2) {
+		t.Fatal("expect different hashes")
+	}
+	if h := mongoProcessHash(0xffff); h > 0xffffff {
+		t.Fatalf("expect 24-bit hash got %x", h)
+	}
+}
@@ -0,0 +1,84 @@
+package buid
+
+import "bytes"
+
+// Compare returns -1, 0 or 1 when id is less than, equal to or greater than
+// other in the big-endian lexicographic byte order
+//
+// Within the same shard index and namespace, an ID with an earlier timestamp is
+// always less than one with a later timestamp.
+func (id ID) Compare(other ID) int {
+	return bytes.Compare(id[:], other[:])
+}
+
+// Less returns whether a is less than b, for use with sort.Slice
+func Less(a, b ID) bool {
+	return a.Compare(b) < 0
+}
+
+// Before returns whether id is less than other, comparing all 128 bits
+//
+// Unlike id.Time().Before(other.Time()), it also orders IDs of the same
+// timestamp by counter and process.
+func (id ID) Before(other ID) bool {
+	return id.Compare(other) < 0
+}
+
+// After returns whether id is greater than other, comparing all 128 bits
+func (id ID) After(other ID) bool {
+	return id.Compare(other) > 0
+}
+
+// IsSameOrBefore returns whether id is equal to or less than other, comparing
+// all 128 bits
+func (id ID) IsSameOrBefore(other ID) bool {
+	return id.Compare(other) <= 0
+}
+
+// IsSameOrAfter returns whether id is equal to or greater than other, comparing
+// all 128 bits
+func (id ID) IsSameOrAfter(other ID) bool {
+	return id.Compare(other) >= 0
+}
+
+// ByTime sorts IDs by time, then by counter and process
+//
+// It compares the hours of the shard parts before the key parts, because a
+// key only encodes the time within its hour.
+type ByTime []ID
+
+func (s ByTime) Len() int           { return len(s) }
+func (s ByTime) Less(i, j int) bool { return compareTime(s[i], s[j]) < 0 }
+func (s ByTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ByShard sorts IDs by shard index, then by time
+type ByShard []ID
+
+func (s ByShard) Len() int { return len(s) }
+func (s ByShard) Less(i, j int) bool {
+	if a, b := s[i].Shard(), s[j].Shard(); a != b {
+		return a < b
+	}
+	return compareTime(s[i], s[j]) < 0
+}
+func (s ByShard) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// ByProcess sorts IDs by process, then by time
+type ByProcess []ID
+
+func (s ByProcess) Len() int { return len(s) }
+func (s ByProcess) Less(i, j int) bool {
+	if a, b := s[i].Process(), s[j].Process(); a != b {
+		return a < b
+	}
+	return compareTime(s[i], s[j]) < 0
+}
+func (s ByProcess) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// compareTime compares the hours and then the key parts of a and b
+func compareTime(a, b ID) int {
+	if c := bytes.Compare(a[4:8], b[4:8]); c != 0 {
+		return c
+	}
+	return bytes.Compare(a[8:], b[8:])
+}
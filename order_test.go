@@ -0,0 +1,146 @@
+package buid
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	p := NewProcess(1)
+	base := time.Now().UTC().Add(time.Hour).Truncate(time.Hour)
+	var ids []ID
+	for _, ts := range []time.Time{
+		base,
+		base.Add(time.Nanosecond),
+		base.Add(time.Second - time.Nanosecond),
+		base.Add(time.Second),
+		base.Add(time.Minute - time.Nanosecond),
+		base.Add(time.Minute),
+		base.Add(time.Hour - time.Nanosecond),
+		base.Add(time.Hour),
+		base.Add(25 * time.Hour),
+	} {
+		ids = append(ids, p.NewID(3, ts))
+	}
+	for i := range ids {
+		if ids[i].Compare(ids[i]) != 0 {
+			t.Fatalf("expect %d equal to itself", i)
+		}
+		for j := i + 1; j < len(ids); j++ {
+			if ids[i].Compare(ids[j]) != -1 || ids[j].Compare(ids[i]) != 1 {
+				t.Fatalf("expect %v < %v", ids[i].Time(), ids[j].Time())
+			}
+			if !Less(ids[i], ids[j]) || Less(ids[j], ids[i]) {
+				t.Fatalf("expect %v < %v", ids[i].Time(), ids[j].Time())
+			}
+		}
+	}
+
+	shuffled := []ID{ids[4], ids[8], ids[0], ids[6], ids[2], ids[1], ids[7], ids[3], ids[5]}
+	sort.Slice(shuffled, func(i, j int) bool { return Less(shuffled[i], shuffled[j]) })
+	for i := range ids {
+		if shuffled[i] != ids[i] {
+			t.Fatalf("expect %v at %d got %v", ids[i], i, shuffled[i])
+		}
+	}
+}
+
+func TestIsSameOrBeforeAfter(t *testing.T) {
+	p := NewProcess(1)
+	a := p.NewID(1, time.Now())
+	b := p.NewID(1, time.Now()) // a later time or a greater counter
+	if !a.IsSameOrBefore(a) || !a.IsSameOrAfter(a) {
+		t.Fatal("expect an ID to be the same as itself")
+	}
+	if !a.IsSameOrBefore(b) || a.IsSameOrAfter(b) {
+		t.Fatalf("expect %v before %v", a, b)
+	}
+	if !b.IsSameOrAfter(a) || b.IsSameOrBefore(a) {
+		t.Fatalf("expect %v after %v", b, a)
+	}
+	// differs only in the process bits, which Time() does not decode
+	c := a
+	c[15]++
+	if !a.Time().Equal(c.Time()) {
+		t.Fatal("expect the same time")
+	}
+	if a.IsSameOrAfter(c) || c.IsSameOrBefore(a) {
+		t.Fatalf("expect %v strictly before %v", a, c)
+	}
+}
+
+func TestBeforeAfter(t *testing.T) {
+	p := NewProcess(1)
+	ts := externalTime(p.t).Add(time.Second)
+	a := p.NewID(1, ts)
+	b := p.NewID(1, ts)
+	if !a.Time().Equal(b.Time()) || a.Counter() == b.Counter() {
+		t.Fatalf("expect the same time and different counters, got %v and %v", a.Verbose(), b.Verbose())
+	}
+	if !a.Before(b) || a.After(b) || !b.After(a) || b.Before(a) {
+		t.Fatalf("expect %v before %v", a.Verbose(), b.Verbose())
+	}
+
+	c := NewProcess(2).NewID(1, ts)
+	if !c.Time().Equal(a.Time()) || c.Counter() != a.Counter() {
+		t.Fatalf("expect the same time and counter, got %v and %v", a.Verbose(), c.Verbose())
+	}
+	if !a.Before(c) || !c.After(a) {
+		t.Fatalf("expect %v before %v", a.Verbose(), c.Verbose())
+	}
+
+	if a.Before(a) || a.After(a) {
+		t.Fatal("expect an ID neither before nor after itself")
+	}
+}
+
+func randomIDs() []ID {
+	base := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := rand.New(rand.NewSource(1))
+	var ids []ID
+	for process := uint16(0); process < 4; process++ {
+		for shard := uint16(0); shard < 4; shard++ {
+			times := make([]time.Time, 20)
+			for i := range times {
+				times[i] = base.Add(time.Duration(r.Int63n(int64(48 * time.Hour))))
+			}
+			ids = append(ids, NewProcess(process).NewIDSlice(shard, times)...)
+		}
+	}
+	r.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	return ids
+}
+
+func TestByTime(t *testing.T) {
+	ids := randomIDs()
+	sort.Sort(ByTime(ids))
+	for i := 1; i < len(ids); i++ {
+		if ids[i].Time().Before(ids[i-1].Time()) {
+			t.Fatalf("expect %v not before %v", ids[i].Verbose(), ids[i-1].Verbose())
+		}
+	}
+}
+
+func TestByShard(t *testing.T) {
+	ids := randomIDs()
+	sort.Sort(ByShard(ids))
+	for i := 1; i < len(ids); i++ {
+		a, b := ids[i-1], ids[i]
+		if b.Shard() < a.Shard() || b.Shard() == a.Shard() && b.Time().Before(a.Time()) {
+			t.Fatalf("expect %v not before %v", b.Verbose(), a.Verbose())
+		}
+	}
+}
+
+func TestByProcess(t *testing.T) {
+	ids := randomIDs()
+	sort.Sort(ByProcess(ids))
+	for i := 1; i < len(ids); i++ {
+		a, b := ids[i-1], ids[i]
+		if b.Process() < a.Process() || b.Process() == a.Process() && b.Time().Before(a.Time()) {
+			t.Fatalf("expect %v not before %v", b.Verbose(), a.Verbose())
+		}
+	}
+}
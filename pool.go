@@ -0,0 +1,20 @@
+package buid
+
+import "sync"
+
+// IDPool is a pool of *ID for code that would otherwise allocate an ID on the
+// heap, e.g. through new(ID) or by boxing it into an interface
+var IDPool = sync.Pool{New: func() interface{} { return new(ID) }}
+
+// GetID returns a zero *ID from IDPool
+func GetID() *ID {
+	return IDPool.Get().(*ID)
+}
+
+// PutID resets id and returns it to IDPool
+//
+// id must not be used after PutID.
+func PutID(id *ID) {
+	*id = ID{}
+	IDPool.Put(id)
+}
@@ -0,0 +1,48 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	id := GetID()
+	if !id.IsZero() {
+		t.Fatal("expect a zero ID")
+	}
+	*id = NewProcess(1).NewID(2, time.Now())
+	PutID(id)
+	if !id.IsZero() {
+		t.Fatal("expect PutID to reset the ID")
+	}
+	if id := GetID(); !id.IsZero() {
+		t.Fatal("expect a zero ID")
+	}
+}
+
+var sinkID *ID
+
+func BenchmarkPoolAlloc(b *testing.B) {
+	p := NewProcess(1)
+	t := time.Now()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		id := GetID()
+		*id = p.NewID(2, t)
+		sinkID = id
+		PutID(id)
+	}
+}
+
+func BenchmarkBareAlloc(b *testing.B) {
+	p := NewProcess(1)
+	t := time.Now()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		id := new(ID)
+		*id = p.NewID(2, t)
+		sinkID = id
+	}
+}
@@ -0,0 +1,60 @@
+package buid
+
+// PrefixTree routes IDs by shard index with a two-level radix tree keyed by
+// the first 2 bytes of an ID
+//
+// The zero value is an empty tree. A PrefixTree is safe for concurrent
+// lookups but not for lookups concurrent with AddRoute.
+type PrefixTree struct {
+	root [256]*prefixNode
+}
+
+type prefixNode struct {
+	values [256]interface{}
+	set    [256]bool
+}
+
+// AddRoute adds or replaces the value of a shard index
+func (t *PrefixTree) AddRoute(shard uint16, value interface{}) {
+	node := t.root[shard>>8]
+	if node == nil {
+		node = new(prefixNode)
+		t.root[shard>>8] = node
+	}
+	node.values[byte(shard)] = value
+	node.set[byte(shard)] = true
+}
+
+// Lookup returns the value of the shard index embedded in id
+func (t *PrefixTree) Lookup(id ID) (interface{}, bool) {
+	node := t.root[id[0]]
+	if node == nil || !node.set[id[1]] {
+		return nil, false
+	}
+	return node.values[id[1]], true
+}
+
+// LookupRange returns the values of all shard indexes within [lo, hi] in the
+// order of shard index
+func (t *PrefixTree) LookupRange(lo, hi uint16) []interface{} {
+	var values []interface{}
+	for i := int(lo >> 8); i <= int(hi>>8); i++ {
+		node := t.root[i]
+		if node == nil {
+			continue
+		}
+		from, to := 0, 255
+		if i == int(lo>>8) {
+			from = int(byte(lo))
+		}
+		if i == int(hi>>8) {
+			to = int(byte(hi))
+		}
+		for j := from; j <= to; j++ {
+			if node.set[j] {
+				values = append(values, node.values[j])
+			}
+		}
+	}
+	return values
+}
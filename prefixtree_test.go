@@ -0,0 +1,66 @@
+package buid
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPrefixTreeLookup(t *testing.T) {
+	var tree PrefixTree
+	p := NewProcess(1)
+	for _, shard := range []uint16{0, 1, 255, 256, 1000, 0xffff} {
+		tree.AddRoute(shard, int(shard))
+	}
+	for _, shard := range []uint16{0, 1, 255, 256, 1000, 0xffff} {
+		value, ok := tree.Lookup(p.NewID(shard, time.Now()))
+		if !ok || value != int(shard) {
+			t.Fatalf("expect %d got %v, %v", shard, value, ok)
+		}
+	}
+	for _, shard := range []uint16{2, 257, 0xfffe} {
+		if value, ok := tree.Lookup(p.NewID(shard, time.Now())); ok {
+			t.Fatalf("expect no route for %d got %v", shard, value)
+		}
+	}
+
+	tree.AddRoute(1000, "replaced")
+	if value, _ := tree.Lookup(p.NewID(1000, time.Now())); value != "replaced" {
+		t.Fatalf("expect replaced got %v", value)
+	}
+}
+
+func TestPrefixTreeLookupRange(t *testing.T) {
+	var tree PrefixTree
+	for _, shard := range []uint16{0xffff, 0, 1, 255, 256, 1000} {
+		tree.AddRoute(shard, int(shard))
+	}
+	for _, tc := range []struct {
+		lo, hi   uint16
+		expected []interface{}
+	}{
+		{0, 0xffff, []interface{}{0, 1, 255, 256, 1000, 0xffff}},
+		{1, 256, []interface{}{1, 255, 256}},
+		{2, 254, nil},
+		{256, 256, []interface{}{256}},
+		{1001, 0xffff, []interface{}{0xffff}},
+		{10, 1, nil},
+	} {
+		if values := tree.LookupRange(tc.lo, tc.hi); !reflect.DeepEqual(values, tc.expected) {
+			t.Fatalf("[%d, %d]: expect %v got %v", tc.lo, tc.hi, tc.expected, values)
+		}
+	}
+}
+
+func BenchmarkPrefixTreeLookup(b *testing.B) {
+	var tree PrefixTree
+	for shard := 0; shard < 500; shard++ {
+		tree.AddRoute(uint16(shard), shard)
+	}
+	id := NewProcess(1).NewID(321, time.Now())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tree.Lookup(id)
+	}
+}
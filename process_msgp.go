@@ -0,0 +1,121 @@
+package buid
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Process is serialized by hand instead of by msgp (see msgp:ignore in
+// buid.go), because its fields are unexported and guarded by a mutex. The
+// state is encoded as a map of "id", "t" and "counter", so that a restored
+// process keeps its ID and never rewinds to a time it has already used.
+
+// DecodeMsg implements msgp.Decodable
+func (p *Process) DecodeMsg(dc *msgp.Reader) (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var n uint32
+	n, err = dc.ReadMapHeader()
+	if err != nil {
+		return
+	}
+	for ; n > 0; n-- {
+		var field []byte
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "id":
+			p.id, err = dc.ReadUint16()
+		case "t":
+			p.t, err = dc.ReadInt64()
+		case "counter":
+			p.counter, err = dc.ReadUint8()
+		default:
+			err = dc.Skip()
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (p *Process) EncodeMsg(en *msgp.Writer) (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err = en.WriteMapHeader(3); err != nil {
+		return
+	}
+	if err = en.WriteString("id"); err != nil {
+		return
+	}
+	if err = en.WriteUint16(p.id); err != nil {
+		return
+	}
+	if err = en.WriteString("t"); err != nil {
+		return
+	}
+	if err = en.WriteInt64(p.t); err != nil {
+		return
+	}
+	if err = en.WriteString("counter"); err != nil {
+		return
+	}
+	return en.WriteUint8(p.counter)
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (p *Process) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, p.Msgsize())
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	o = msgp.AppendMapHeader(o, 3)
+	o = msgp.AppendString(o, "id")
+	o = msgp.AppendUint16(o, p.id)
+	o = msgp.AppendString(o, "t")
+	o = msgp.AppendInt64(o, p.t)
+	o = msgp.AppendString(o, "counter")
+	o = msgp.AppendUint8(o, p.counter)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (p *Process) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var n uint32
+	n, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		return
+	}
+	for ; n > 0; n-- {
+		var field []byte
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "id":
+			p.id, bts, err = msgp.ReadUint16Bytes(bts)
+		case "t":
+			p.t, bts, err = msgp.ReadInt64Bytes(bts)
+		case "counter":
+			p.counter, bts, err = msgp.ReadUint8Bytes(bts)
+		default:
+			bts, err = msgp.Skip(bts)
+		}
+		if err != nil {
+			return
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (p *Process) Msgsize() (s int) {
+	s = 1 + 3 + msgp.Uint16Size + 2 + msgp.Int64Size + 8 + msgp.Uint8Size
+	return
+}
@@ -0,0 +1,169 @@
+package buid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+var (
+	_ msgp.Encodable   = &Process{}
+	_ msgp.Decodable   = &Process{}
+	_ msgp.Marshaler   = &Process{}
+	_ msgp.Unmarshaler = &Process{}
+	_ msgp.Sizer       = &Process{}
+)
+
+func TestProcessMsgp(t *testing.T) {
+	ts := time.Date(2100, 1, 2, 3, 4, 5, 6, time.UTC)
+	p := NewProcess(5)
+	last := p.NewIDs(1, ts, 3)[2]
+
+	bts, err := p.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bts) > p.Msgsize() {
+		t.Fatalf("expect at most %d bytes got %d", p.Msgsize(), len(bts))
+	}
+	q := &Process{}
+	left, err := q.UnmarshalMsg(bts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) > 0 {
+		t.Fatalf("expect no bytes left got %q", left)
+	}
+	if q.id != p.id || q.t != p.t || q.counter != p.counter {
+		t.Fatalf("expect %d/%d/%d got %d/%d/%d", p.id, p.t, p.counter, q.id, q.t, q.counter)
+	}
+
+	// the restored process never rewinds
+	id := q.NewID(1, ts.Add(-time.Hour))
+	if id.Process() != 5 {
+		t.Fatalf("expect 5 got %d", id.Process())
+	}
+	if !id.After(last) {
+		t.Fatalf("expect %v after %v", id.Verbose(), last.Verbose())
+	}
+}
+
+func TestProcessEncodeDecode(t *testing.T) {
+	p := NewProcess(7)
+	p.NewID(1, time.Now())
+	var buf bytes.Buffer
+	if err := msgp.Encode(&buf, p); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() > p.Msgsize() {
+		t.Fatalf("expect at most %d bytes got %d", p.Msgsize(), buf.Len())
+	}
+	q := &Process{}
+	if err := msgp.Decode(&buf, q); err != nil {
+		t.Fatal(err)
+	}
+	if q.id != p.id || q.t != p.t || q.counter != p.counter {
+		t.Fatalf("expect %d/%d/%d got %d/%d/%d", p.id, p.t, p.counter, q.id, q.t, q.counter)
+	}
+}
+
+func TestProcessUnmarshalMsgUnknownField(t *testing.T) {
+	bts := msgp.AppendMapHeader(nil, 2)
+	bts = msgp.AppendString(bts, "unknown")
+	bts = msgp.AppendString(bts, "value")
+	bts = msgp.AppendString(bts, "id")
+	bts = msgp.AppendUint16(bts, 9)
+
+	p := &Process{}
+	if _, err := p.UnmarshalMsg(bts); err != nil {
+		t.Fatal(err)
+	}
+	if p.id != 9 {
+		t.Fatalf("expect 9 got %d", p.id)
+	}
+	p = &Process{}
+	if err := p.DecodeMsg(msgp.NewReader(bytes.NewReader(bts))); err != nil {
+		t.Fatal(err)
+	}
+	if p.id != 9 {
+		t.Fatalf("expect 9 got %d", p.id)
+	}
+	if _, err := p.UnmarshalMsg(bts[:len(bts)-1]); err == nil {
+		t.Fatal("expect error for truncated input")
+	}
+}
+
+func FuzzProcessMsgp(f *testing.F) {
+	f.Add(uint16(0), int64(0), uint8(0))
+	f.Add(uint16(0xffff), int64(-1), uint8(maxCounter+1))
+	f.Add(uint16(42), internalTime(time.Now()), uint8(7))
+	f.Fuzz(func(t *testing.T, id uint16, ts int64, counter uint8) {
+		p := &Process{id: id, t: ts, counter: counter}
+		bts, err := p.MarshalMsg(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bts) > p.Msgsize() {
+			t.Fatalf("expect at most %d bytes got %d", p.Msgsize(), len(bts))
+		}
+		q := &Process{}
+		if _, err := q.UnmarshalMsg(bts); err != nil {
+			t.Fatal(err)
+		}
+		if q.id != id || q.t != ts || q.counter != counter {
+			t.Fatalf("expect %d/%d/%d got %d/%d/%d", id, ts, counter, q.id, q.t, q.counter)
+		}
+	})
+}
+
+func FuzzMsgpRoundTrip(f *testing.F) {
+	f.Add(make([]byte, 16))
+	f.Add(bytes.Repeat([]byte{0xff}, 16))
+	id := NewProcess(3).NewID(2, time.Now())
+	f.Add(id[:])
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) < 16 {
+			return
+		}
+		var id ID
+		copy(id[:], data)
+		shard, key := id.Split()
+		for _, c := range []struct {
+			v interface {
+				msgp.Marshaler
+				msgp.Unmarshaler
+				msgp.Sizer
+			}
+			zero interface{ msgp.Unmarshaler }
+			raw  []byte
+		}{
+			{&id, &ID{}, id[:]},
+			{&shard, &Shard{}, shard[:]},
+			{&key, &Key{}, key[:]},
+		} {
+			bts, err := c.v.MarshalMsg(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(bts) > c.v.Msgsize() {
+				t.Fatalf("expect at most %d bytes got %d", c.v.Msgsize(), len(bts))
+			}
+			// msgpack bin 8 format, not an array
+			if expected := append([]byte{0xc4, byte(len(c.raw))}, c.raw...); !bytes.Equal(bts, expected) {
+				t.Fatalf("expect %x got %x", expected, bts)
+			}
+			left, err := c.zero.UnmarshalMsg(bts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(left) > 0 {
+				t.Fatalf("expect no bytes left got %q", left)
+			}
+			if actual, _ := c.zero.(msgp.Marshaler).MarshalMsg(nil); !bytes.Equal(actual, bts) {
+				t.Fatalf("expect %x got %x", bts, actual)
+			}
+		}
+	})
+}
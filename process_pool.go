@@ -0,0 +1,43 @@
+package buid
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ProcessPool generates BUIDs with multiple processes in round-robin, so that
+// the counters of N processes allow N times as many IDs within a nanosecond
+type ProcessPool struct {
+	processes []*Process
+	next      atomic.Uint64
+}
+
+// NewProcessPool returns a pool of a new Process for each of ids, which must
+// not be empty and should be unique among all the processes generating IDs
+func NewProcessPool(ids []uint16) *ProcessPool {
+	if len(ids) == 0 {
+		panic("buid: empty process pool")
+	}
+	pool := &ProcessPool{processes: make([]*Process, len(ids))}
+	for i, id := range ids {
+		pool.processes[i] = NewProcess(id)
+	}
+	return pool
+}
+
+// NewID generates a new BUID from a shard index and a timestamp with the next
+// process of the pool
+func (pool *ProcessPool) NewID(shard uint16, t time.Time) ID {
+	return pool.process().NewID(shard, t)
+}
+
+// NewIDNow generates a new BUID from a shard index and the current time with
+// the next process of the pool
+func (pool *ProcessPool) NewIDNow(shard uint16) ID {
+	return pool.process().NewIDNow(shard)
+}
+
+func (pool *ProcessPool) process() *Process {
+	i := (pool.next.Add(1) - 1) % uint64(len(pool.processes))
+	return pool.processes[i]
+}
@@ -0,0 +1,65 @@
+package buid
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProcessPool(t *testing.T) {
+	const n = 4
+	pool := NewProcessPool([]uint16{1, 2, 3, 4})
+	// a fixed time later than the initial time of all the processes
+	ts := time.Now().Add(time.Hour)
+	m := make(map[ID]bool)
+	for i := 0; i < n*(maxCounter+1); i++ {
+		id := pool.NewID(2, ts)
+		if m[id] {
+			t.Fatal("duplication detected")
+		}
+		m[id] = true
+		if !id.Time().Equal(ts) {
+			t.Fatalf("expect %v got %v", ts, id.Time())
+		}
+		if expected := uint16(i%n + 1); id.Process() != expected {
+			t.Fatalf("expect %d got %d", expected, id.Process())
+		}
+		if expected := uint16(i / n); id.Counter() != expected {
+			t.Fatalf("expect %d got %d", expected, id.Counter())
+		}
+	}
+	for _, p := range pool.processes {
+		if c := p.ContentionCount(); c != 0 {
+			t.Fatalf("expect 0 got %d", c)
+		}
+	}
+}
+
+func TestProcessPoolConcurrent(t *testing.T) {
+	pool := NewProcessPool([]uint16{1, 2, 3})
+	var wg sync.WaitGroup
+	ids := make([][]ID, 6)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				ids[i] = append(ids[i], pool.NewIDNow(2))
+			}
+		}(i)
+	}
+	wg.Wait()
+	m := make(map[ID]bool)
+	for _, s := range ids {
+		for _, id := range s {
+			if m[id] {
+				t.Fatal("duplication detected")
+			}
+			m[id] = true
+		}
+	}
+}
+
+func TestNewProcessPoolEmpty(t *testing.T) {
+	expectPanic(t, func() { NewProcessPool(nil) })
+}
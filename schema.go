@@ -0,0 +1,54 @@
+package buid
+
+import "encoding/json"
+
+const (
+	// OpenAPIFormat is the OpenAPI 3.x format name of a BUID string
+	OpenAPIFormat = "buid"
+	// OpenAPIPattern is the OpenAPI 3.x pattern of a BUID string
+	//
+	// The base-62 encoding is not fixed width: an ID with a small shard index
+	// encodes to 20 characters but a full 128-bit value needs up to 22.
+	OpenAPIPattern = "^[0-9A-Za-z]{1,22}$"
+
+	openAPIExample = "0skIcr10rnBGT3wdrHO2"
+
+	// a 64-bit part encodes to at most 11 base-62 characters
+	partPattern  = "^[0-9A-Za-z]{1,11}$"
+	shardExample = "01hVwxoVC0"
+	keyExample   = "00000002"
+)
+
+// ToOpenAPIExample returns a valid BUID string for use in API documentation
+func ToOpenAPIExample() string {
+	return openAPIExample
+}
+
+type jsonSchema struct {
+	Schema   string   `json:"$schema"`
+	Type     string   `json:"type"`
+	Pattern  string   `json:"pattern"`
+	Examples []string `json:"examples"`
+}
+
+func (s jsonSchema) marshal() []byte {
+	s.Schema = "http://json-schema.org/draft-07/schema#"
+	s.Type = "string"
+	buf, _ := json.Marshal(s)
+	return buf
+}
+
+// BUIDJSONSchema returns the JSON Schema (Draft 7) of ID
+func BUIDJSONSchema() []byte {
+	return jsonSchema{Pattern: OpenAPIPattern, Examples: []string{openAPIExample}}.marshal()
+}
+
+// KeyJSONSchema returns the JSON Schema (Draft 7) of Key
+func KeyJSONSchema() []byte {
+	return jsonSchema{Pattern: partPattern, Examples: []string{keyExample}}.marshal()
+}
+
+// ShardJSONSchema returns the JSON Schema (Draft 7) of Shard
+func ShardJSONSchema() []byte {
+	return jsonSchema{Pattern: partPattern, Examples: []string{shardExample}}.marshal()
+}
@@ -0,0 +1,71 @@
+package buid
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestOpenAPIExample(t *testing.T) {
+	var id ID
+	if err := id.UnmarshalText([]byte(ToOpenAPIExample())); err != nil {
+		t.Fatal(err)
+	}
+	if id.String() != ToOpenAPIExample() {
+		t.Fatalf("expect %v got %v", ToOpenAPIExample(), id.String())
+	}
+}
+
+func TestOpenAPIPattern(t *testing.T) {
+	pattern := regexp.MustCompile(OpenAPIPattern)
+	if !pattern.MatchString(ToOpenAPIExample()) {
+		t.Fatalf("expect %s to match %s", ToOpenAPIExample(), OpenAPIPattern)
+	}
+	p := NewProcess(0xffff)
+	for _, shard := range []uint16{0, 1, 0xff, 0x100, 0xffff} {
+		id := p.NewID(shard, time.Now())
+		if !pattern.MatchString(id.String()) {
+			t.Fatalf("expect %s to match %s", id.String(), OpenAPIPattern)
+		}
+	}
+	var max ID
+	for i := range max {
+		max[i] = 0xff
+	}
+	if !pattern.MatchString(max.String()) {
+		t.Fatalf("expect %s to match %s", max.String(), OpenAPIPattern)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	id := NewProcess(0xffff).NewID(0xffff, time.Now())
+	shard, key := id.Split()
+	for _, tc := range []struct {
+		schema []byte
+		valid  []string
+	}{
+		{BUIDJSONSchema(), []string{id.String()}},
+		{KeyJSONSchema(), []string{key.String()}},
+		{ShardJSONSchema(), []string{shard.String()}},
+	} {
+		var schema struct {
+			Schema   string   `json:"$schema"`
+			Type     string   `json:"type"`
+			Pattern  string   `json:"pattern"`
+			Examples []string `json:"examples"`
+		}
+		if err := json.Unmarshal(tc.schema, &schema); err != nil {
+			t.Fatal(err)
+		}
+		if schema.Type != "string" {
+			t.Fatalf("expect string got %s", schema.Type)
+		}
+		pattern := regexp.MustCompile(schema.Pattern)
+		for _, s := range append(schema.Examples, tc.valid...) {
+			if !pattern.MatchString(s) {
+				t.Fatalf("expect %s to match %s", s, schema.Pattern)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package buid
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// BenchmarkCompareSerialization measures an encode and a decode of 1000 IDs
+// per op for each serialization, see the serialization guide in README.md
+func BenchmarkCompareSerialization(b *testing.B) {
+	const n = 1000
+	ids := NewProcess(1).NewIDs(2, time.Now(), n)
+	for _, bc := range []struct {
+		name   string
+		encode func(id *ID, buf []byte) ([]byte, error)
+		decode func(id *ID, buf []byte) error
+	}{
+		{
+			name: "raw",
+			encode: func(id *ID, buf []byte) ([]byte, error) {
+				return append(buf[:0], id[:]...), nil
+			},
+			decode: func(id *ID, buf []byte) error {
+				copy(id[:], buf)
+				return nil
+			},
+		},
+		{
+			name: "base62",
+			encode: func(id *ID, buf []byte) ([]byte, error) {
+				return id.MarshalText()
+			},
+			decode: func(id *ID, buf []byte) error {
+				return id.UnmarshalText(buf)
+			},
+		},
+		{
+			name: "json",
+			encode: func(id *ID, buf []byte) ([]byte, error) {
+				return json.Marshal(id)
+			},
+			decode: func(id *ID, buf []byte) error {
+				return json.Unmarshal(buf, id)
+			},
+		},
+		{
+			name: "msgp",
+			encode: func(id *ID, buf []byte) ([]byte, error) {
+				return id.MarshalMsg(buf[:0])
+			},
+			decode: func(id *ID, buf []byte) error {
+				_, err := id.UnmarshalMsg(buf)
+				return err
+			},
+		},
+		{
+			name: "hex",
+			encode: func(id *ID, buf []byte) ([]byte, error) {
+				return hex.AppendEncode(buf[:0], id[:]), nil
+			},
+			decode: func(id *ID, buf []byte) error {
+				_, err := hex.Decode(id[:], buf)
+				return err
+			},
+		},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var id ID
+			buf := make([]byte, 0, 64)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := range ids {
+					var err error
+					buf, err = bc.encode(&ids[j], buf)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if err := bc.decode(&id, buf); err != nil {
+						b.Fatal(err)
+					}
+					if id != ids[j] {
+						b.Fatalf("expect %v got %v", ids[j], id)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package buid
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+// ShardMap routes string keys, e.g. user IDs, to a changing set of active
+// shard indices with rendezvous (highest random weight) hashing
+//
+// Unlike ConsistentShard, the indices need not be contiguous: removing an
+// index only moves the keys routed to it, and adding one only moves the keys
+// it wins. It is safe for concurrent use.
+type ShardMap struct {
+	indices []uint16 // sorted and unique
+	mu      sync.RWMutex
+}
+
+// NewShardMap returns a ShardMap of the active shard indices, duplicates are
+// ignored
+func NewShardMap(indices []uint16) *ShardMap {
+	m := &ShardMap{}
+	for _, index := range indices {
+		m.add(index)
+	}
+	return m
+}
+
+// Add adds a shard index, it is a no-op if the index is already active
+func (m *ShardMap) Add(index uint16) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.add(index)
+}
+
+func (m *ShardMap) add(index uint16) {
+	i := sort.Search(len(m.indices), func(i int) bool { return m.indices[i] >= index })
+	if i < len(m.indices) && m.indices[i] == index {
+		return
+	}
+	m.indices = append(m.indices, 0)
+	copy(m.indices[i+1:], m.indices[i:])
+	m.indices[i] = index
+}
+
+// Remove removes a shard index, it is a no-op if the index is not active
+func (m *ShardMap) Remove(index uint16) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := sort.Search(len(m.indices), func(i int) bool { return m.indices[i] >= index })
+	if i < len(m.indices) && m.indices[i] == index {
+		m.indices = append(m.indices[:i], m.indices[i+1:]...)
+	}
+}
+
+// Indices returns a sorted copy of the active shard indices
+func (m *ShardMap) Indices() []uint16 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]uint16(nil), m.indices...)
+}
+
+// Route returns the active shard index of key, which is deterministic for the
+// same key and set of indices. It returns 0 if there is no active index.
+func (m *ShardMap) Route(key string) uint16 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	k := h.Sum64()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var (
+		best      uint16
+		bestScore uint64
+	)
+	for i, index := range m.indices {
+		if score := rendezvousScore(k, index); i == 0 || score > bestScore {
+			best, bestScore = index, score
+		}
+	}
+	return best
+}
+
+// rendezvousScore returns the weight of a shard index for a key hash, mixed
+// with the SplitMix64 finalizer so that close indices get independent scores
+func rendezvousScore(k uint64, index uint16) uint64 {
+	z := k ^ (uint64(index)+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
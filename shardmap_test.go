@@ -0,0 +1,117 @@
+package buid
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestShardMap(t *testing.T) {
+	m := NewShardMap([]uint16{7, 3, 5, 3})
+	if expected, actual := []uint16{3, 5, 7}, m.Indices(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expect %v got %v", expected, actual)
+	}
+	m.Add(1)
+	m.Add(5)
+	m.Remove(7)
+	m.Remove(9)
+	if expected, actual := []uint16{1, 3, 5}, m.Indices(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expect %v got %v", expected, actual)
+	}
+
+	other := NewShardMap([]uint16{5, 1, 3})
+	counts := make(map[uint16]int)
+	for i := 0; i < 3000; i++ {
+		key := "user-" + strconv.Itoa(i)
+		index := m.Route(key)
+		if index != other.Route(key) {
+			t.Fatalf("expect the same route for %s", key)
+		}
+		counts[index]++
+	}
+	for _, index := range m.Indices() {
+		if counts[index] < 800 {
+			t.Fatalf("expect an even distribution got %v", counts)
+		}
+	}
+
+	if index := NewShardMap(nil).Route("user"); index != 0 {
+		t.Fatalf("expect 0 got %d", index)
+	}
+}
+
+func TestShardMapRemap(t *testing.T) {
+	const n = 10000
+	m := NewShardMap([]uint16{0, 10, 20, 30, 40})
+	before := make([]uint16, n)
+	for i := range before {
+		before[i] = m.Route(strconv.Itoa(i))
+	}
+
+	m.Remove(20)
+	for i, prev := range before {
+		index := m.Route(strconv.Itoa(i))
+		if prev != 20 && index != prev {
+			t.Fatalf("expect key %d to stay in %d got %d", i, prev, index)
+		}
+		if index == 20 {
+			t.Fatalf("expect key %d to leave the removed shard", i)
+		}
+	}
+
+	m.Add(20)
+	m.Add(50)
+	moved := 0
+	for i, prev := range before {
+		index := m.Route(strconv.Itoa(i))
+		if index != prev {
+			if index != 50 {
+				t.Fatalf("expect key %d to stay in %d or move to 50 got %d", i, prev, index)
+			}
+			moved++
+		}
+	}
+	// about 1/6 of the keys move to the new shard
+	if moved < n/6*8/10 || moved > n/6*12/10 {
+		t.Fatalf("expect about %d keys moved got %d", n/6, moved)
+	}
+}
+
+func TestShardMapConcurrent(t *testing.T) {
+	m := NewShardMap([]uint16{1, 2, 3})
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Route(strconv.Itoa(i))
+			}
+		}()
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Add(uint16(10 + g))
+				m.Remove(uint16(10 + g))
+			}
+		}(g)
+	}
+	wg.Wait()
+	if expected, actual := []uint16{1, 2, 3}, m.Indices(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expect %v got %v", expected, actual)
+	}
+}
+
+func BenchmarkShardMapRoute(b *testing.B) {
+	indices := make([]uint16, 64)
+	for i := range indices {
+		indices[i] = uint16(i)
+	}
+	m := NewShardMap(indices)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Route("user-12345")
+	}
+}
@@ -0,0 +1,75 @@
+package buid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+// Value implements driver.Valuer and stores the ID as BINARY(16) or bytea
+func (id ID) Value() (driver.Value, error) {
+	return append([]byte(nil), id[:]...), nil
+}
+
+// Scan implements sql.Scanner
+//
+// The source may be raw bytes or a PostgreSQL hex string like `\x0102...`,
+// which is what bytea columns return under the text protocol. NULL scans to
+// the zero ID.
+func (id *ID) Scan(src interface{}) error {
+	return scanBytes(id[:], src, "ID")
+}
+
+// Value implements driver.Valuer and stores the Key as BINARY(8) or bytea
+func (k Key) Value() (driver.Value, error) {
+	return append([]byte(nil), k[:]...), nil
+}
+
+// Scan implements sql.Scanner, see ID.Scan for accepted sources
+func (k *Key) Scan(src interface{}) error {
+	return scanBytes(k[:], src, "Key")
+}
+
+// Value implements driver.Valuer and stores the Shard as BINARY(8) or bytea
+func (s Shard) Value() (driver.Value, error) {
+	return append([]byte(nil), s[:]...), nil
+}
+
+// Scan implements sql.Scanner, see ID.Scan for accepted sources
+func (s *Shard) Scan(src interface{}) error {
+	return scanBytes(s[:], src, "Shard")
+}
+
+var postgresHexPrefix = []byte(`\x`)
+
+func scanBytes(dst []byte, src interface{}, typ string) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		for i := range dst {
+			dst[i] = 0
+		}
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("cannot scan %T into %s", src, typ)
+	}
+	// raw bytes may start with `\x` too, so only the exact length of the hex
+	// form is decoded
+	if len(data) == len(postgresHexPrefix)+hex.EncodedLen(len(dst)) && bytes.HasPrefix(data, postgresHexPrefix) {
+		decoded, err := hex.DecodeString(string(data[len(postgresHexPrefix):]))
+		if err != nil {
+			return err
+		}
+		data = decoded
+	}
+	if len(data) != len(dst) {
+		return fmt.Errorf("%s length must be %d bit", typ, len(dst)*8)
+	}
+	copy(dst, data)
+	return nil
+}
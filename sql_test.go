@@ -0,0 +1,130 @@
+package buid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+var (
+	_ driver.Valuer = ID{}
+	_ sql.Scanner   = &ID{}
+	_ driver.Valuer = Key{}
+	_ sql.Scanner   = &Key{}
+	_ driver.Valuer = Shard{}
+	_ sql.Scanner   = &Shard{}
+)
+
+func TestSQL(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	shard, key := id.Split()
+
+	v, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !driver.IsValue(v) {
+		t.Fatalf("expect driver value got %T", v)
+	}
+	var id2 ID
+	if err := id2.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+
+	v, _ = key.Value()
+	var key2 Key
+	if err := key2.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if key2 != key {
+		t.Fatalf("expect %v got %v", key, key2)
+	}
+
+	v, _ = shard.Value()
+	var shard2 Shard
+	if err := shard2.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if shard2 != shard {
+		t.Fatalf("expect %v got %v", shard, shard2)
+	}
+}
+
+func TestSQLScanString(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	var id2 ID
+	if err := id2.Scan(string(id[:])); err != nil {
+		t.Fatal(err)
+	}
+	if id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+	id2 = ID{}
+	if err := id2.Scan(`\x` + hex.EncodeToString(id[:])); err != nil {
+		t.Fatal(err)
+	}
+	if id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+	if err := id2.Scan(nil); err != nil || !id2.IsZero() {
+		t.Fatalf("expect zero ID got %v, %v", id2, err)
+	}
+}
+
+func TestSQLScanRawHexPrefix(t *testing.T) {
+	// the raw bytes start with `\x`
+	id := NewProcess(1).NewID(0x5c78, time.Now())
+	shard, key := id.Split()
+	key[0], key[1] = 0x5c, 0x78
+	value, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id2 ID
+	if err := id2.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+	value, err = shard.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shard2 Shard
+	if err := shard2.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if shard2 != shard {
+		t.Fatalf("expect %v got %v", shard, shard2)
+	}
+	value, err = key.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key2 Key
+	if err := key2.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if key2 != key {
+		t.Fatalf("expect %v got %v", key, key2)
+	}
+}
+
+func TestSQLScanError(t *testing.T) {
+	var id ID
+	var shard Shard
+	for _, src := range []interface{}{int64(1), []byte{1, 2}, `\xzz`} {
+		if err := id.Scan(src); err == nil {
+			t.Fatalf("expect error for %v", src)
+		}
+	}
+	if err := shard.Scan(make([]byte, 16)); err == nil {
+		t.Fatal("expect error")
+	}
+}
@@ -0,0 +1,64 @@
+package buid
+
+import (
+	"context"
+	"time"
+)
+
+// StreamOption configures the channel returned by Stream
+type StreamOption func(*streamOptions)
+
+type streamOptions struct {
+	buffer int
+}
+
+// WithChannelBuffer sets the buffer size of the channel, which is unbuffered
+// by default
+func WithChannelBuffer(n int) StreamOption {
+	return func(o *streamOptions) { o.buffer = n }
+}
+
+// Stream returns a never-ending channel of new BUIDs of shard generated at the
+// current time, which is closed once ctx is done
+func (p *Process) Stream(ctx context.Context, shard uint16, opts ...StreamOption) <-chan ID {
+	var o streamOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return p.stream(ctx, shard, o.buffer, time.Time{})
+}
+
+// IDsUntil returns a channel of new BUIDs of shard generated at the current
+// time, which is closed once the wall clock passes until or ctx is done
+func (p *Process) IDsUntil(ctx context.Context, shard uint16, until time.Time) <-chan ID {
+	return p.stream(ctx, shard, 0, until)
+}
+
+// stream generates BUIDs into a channel until ctx is done or, if until is not
+// zero, the clock passes until
+func (p *Process) stream(ctx context.Context, shard uint16, buffer int, until time.Time) <-chan ID {
+	ch := make(chan ID, buffer)
+	go func() {
+		defer close(ch)
+		var deadline <-chan time.Time // nil channel blocks forever
+		if !until.IsZero() {
+			timer := time.NewTimer(time.Until(until))
+			defer timer.Stop()
+			deadline = timer.C
+		}
+		for {
+			now := p.now()
+			if !until.IsZero() && now.After(until) {
+				return
+			}
+			select {
+			case ch <- p.NewID(shard, now):
+			case <-deadline:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
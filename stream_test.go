@@ -0,0 +1,124 @@
+package buid
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIDsUntil(t *testing.T) {
+	until := time.Now().Add(50 * time.Millisecond)
+	ch := NewProcess(1).IDsUntil(context.Background(), 2, until)
+	m := make(map[ID]bool)
+	timeout := time.After(time.Second)
+	for done := false; !done; {
+		select {
+		case id, ok := <-ch:
+			if !ok {
+				done = true
+				break
+			}
+			if m[id] {
+				t.Fatal("duplication detected")
+			}
+			m[id] = true
+			if id.Time().After(until) {
+				t.Fatalf("expect ID before %v got %v", until, id.Time())
+			}
+		case <-timeout:
+			t.Fatal("expect the channel to be closed after the deadline")
+		}
+	}
+	if len(m) == 0 {
+		t.Fatal("expect IDs generated before the deadline")
+	}
+	if time.Now().Before(until) {
+		t.Fatal("expect the channel to be closed after the deadline")
+	}
+}
+
+func TestIDsUntilNoReader(t *testing.T) {
+	ch := NewProcess(1).IDsUntil(context.Background(), 2, time.Now().Add(10*time.Millisecond))
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("expect the goroutine to exit without a reader")
+	}
+	if _, ok := <-ch; ok {
+		t.Fatal("expect the channel to be closed")
+	}
+}
+
+func TestIDsUntilCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := NewProcess(1).IDsUntil(ctx, 2, time.Now().Add(time.Hour))
+	<-ch
+	cancel()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("expect the channel to be closed after cancellation")
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	for _, buffer := range []int{0, 16} {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := NewProcess(1).Stream(ctx, 2, WithChannelBuffer(buffer))
+		if cap(ch) != buffer {
+			t.Fatalf("expect %d got %d", buffer, cap(ch))
+		}
+		m := make(map[ID]bool)
+		drain := time.After(100 * time.Millisecond)
+		for draining := true; draining; {
+			select {
+			case id := <-ch:
+				if m[id] {
+					t.Fatal("duplication detected")
+				}
+				m[id] = true
+				if id.Shard() != 2 {
+					t.Fatalf("expect 2 got %d", id.Shard())
+				}
+			case <-drain:
+				draining = false
+			}
+		}
+		if len(m) == 0 {
+			t.Fatal("expect IDs generated")
+		}
+		cancel()
+		timeout := time.After(time.Second)
+		for closed := false; !closed; {
+			select {
+			case _, ok := <-ch:
+				closed = !ok
+			case <-timeout:
+				t.Fatal("expect the channel to be closed after cancellation")
+			}
+		}
+	}
+}
+
+func BenchmarkStream(b *testing.B) {
+	for _, buffer := range []int{0, 64} {
+		b.Run(fmt.Sprintf("buffer=%d", buffer), func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ch := NewProcess(1).Stream(ctx, 2, WithChannelBuffer(buffer))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				<-ch
+			}
+		})
+	}
+}
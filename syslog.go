@@ -0,0 +1,32 @@
+package buid
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// SyslogEnterpriseNumber is the private enterprise number in the SD-ID of the
+// RFC 5424 structured data element, 32473 is reserved for documentation
+var SyslogEnterpriseNumber = 32473
+
+// MarshalSyslog returns an RFC 5424 structured data element of the ID, e.g.
+// [buid@32473 id="0skIcr10rnBGT3wdrHO2"]
+//
+// The base-62 alphabet needs no escaping in a PARAM-VALUE.
+func (id ID) MarshalSyslog() string {
+	return syslogPrefix() + id.String() + `"]`
+}
+
+// ParseFromSyslog parses a structured data element returned by MarshalSyslog
+func ParseFromSyslog(element string) (ID, error) {
+	prefix := syslogPrefix()
+	if !strings.HasPrefix(element, prefix) || !strings.HasSuffix(element, `"]`) || len(element) < len(prefix)+2 {
+		return ID{}, errors.New("invalid BUID syslog structured data element")
+	}
+	return ParseID(element[len(prefix) : len(element)-2])
+}
+
+func syslogPrefix() string {
+	return "[buid@" + strconv.Itoa(SyslogEnterpriseNumber) + ` id="`
+}
@@ -0,0 +1,50 @@
+package buid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyslog(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	s := id.MarshalSyslog()
+	if expected := `[buid@32473 id="` + id.String() + `"]`; s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+	id2, err := ParseFromSyslog(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+}
+
+func TestSyslogEnterpriseNumber(t *testing.T) {
+	defer func(n int) { SyslogEnterpriseNumber = n }(SyslogEnterpriseNumber)
+	SyslogEnterpriseNumber = 12345
+	id := NewProcess(2).NewID(1, time.Now())
+	s := id.MarshalSyslog()
+	if expected := `[buid@12345 id="` + id.String() + `"]`; s != expected {
+		t.Fatalf("expect %s got %s", expected, s)
+	}
+	if id2, err := ParseFromSyslog(s); err != nil || id2 != id {
+		t.Fatalf("expect %v got %v, %v", id, id2, err)
+	}
+}
+
+func TestParseFromSyslogError(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	for _, s := range []string{
+		"",
+		`[buid@32473 id=""]`,
+		`[buid@32473 id="` + id.String() + `"`,
+		`[buid@1 id="` + id.String() + `"]`,
+		`[uuid@32473 id="` + id.String() + `"]`,
+		`[buid@32473 id="!!"]`,
+	} {
+		if _, err := ParseFromSyslog(s); err == nil {
+			t.Fatalf("expect error for %s", s)
+		}
+	}
+}
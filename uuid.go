@@ -0,0 +1,59 @@
+package buid
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+// ToUUID returns the ID bytes as a UUID value without reordering, e.g. for a
+// PostgreSQL uuid column
+func (id ID) ToUUID() [16]byte {
+	return [16]byte(id)
+}
+
+// FromUUID returns the ID of a UUID value returned by ToUUID
+func FromUUID(b [16]byte) ID {
+	return ID(b)
+}
+
+// ToTimeOrderedUUID returns the ID bytes reordered into a hyphenated UUID
+// string with the time bytes in the high bits, so that the strings sort by
+// time like UUID v1 strings in databases such as PostgreSQL
+//
+// The bytes are laid out as hours and key time, counter (10 bytes), shard and
+// namespace (4 bytes), then process (2 bytes). It is a display format, not a
+// semantic conversion: the result is not a standards-compliant UUID v1.
+func (id ID) ToTimeOrderedUUID() string {
+	var b [16]byte
+	copy(b[:10], id[4:14])
+	copy(b[10:14], id[:4])
+	copy(b[14:], id[14:])
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
+
+// NewIDFromTimeOrderedUUID parses a string returned by ToTimeOrderedUUID
+func NewIDFromTimeOrderedUUID(s string) (ID, error) {
+	var id ID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return id, errors.New("invalid UUID format")
+	}
+	var b [16]byte
+	h := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(b[:], []byte(h)); err != nil {
+		return id, err
+	}
+	copy(id[4:14], b[:10])
+	copy(id[:4], b[10:14])
+	copy(id[14:], b[14:])
+	return id, nil
+}
@@ -0,0 +1,51 @@
+package buid
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestTimeOrderedUUID(t *testing.T) {
+	p := NewProcess(0xabcd)
+	ts := time.Now()
+	var ids []ID
+	var uuids []string
+	for i, shard := range []uint16{0xffff, 3, 0x1234, 0} {
+		id := p.NewID(shard, ts.Add(time.Duration(i)*time.Hour+time.Duration(i)*time.Millisecond)).SetNamespace(0x0102)
+		ids = append(ids, id)
+		uuids = append(uuids, id.ToTimeOrderedUUID())
+	}
+	for i, s := range uuids {
+		if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			t.Fatalf("unexpected UUID format %s", s)
+		}
+		id, err := NewIDFromTimeOrderedUUID(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != ids[i] {
+			t.Fatalf("expect %v got %v", ids[i], id)
+		}
+	}
+	if !sort.StringsAreSorted(uuids) {
+		t.Fatalf("expect UUIDs sorted by time %v", uuids)
+	}
+
+	for _, s := range []string{"", uuids[0][:35], uuids[0][:8] + "x" + uuids[0][9:], "zz" + uuids[0][2:]} {
+		if _, err := NewIDFromTimeOrderedUUID(s); err == nil {
+			t.Fatalf("expect error for %s", s)
+		}
+	}
+}
+
+func TestToUUID(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	u := id.ToUUID()
+	if string(u[:]) != string(id[:]) {
+		t.Fatalf("expect %x got %x", id[:], u[:])
+	}
+	if id2 := FromUUID(id.ToUUID()); id2 != id {
+		t.Fatalf("expect %v got %v", id, id2)
+	}
+}
@@ -0,0 +1,48 @@
+package buid
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML implements yaml.Marshaler with the base-62 encoded string
+func (id ID) MarshalYAML() (interface{}, error) {
+	return id.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (id *ID) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshalYAML(value, "ID", id.UnmarshalText)
+}
+
+// MarshalYAML implements yaml.Marshaler with the base-62 encoded string
+func (k Key) MarshalYAML() (interface{}, error) {
+	return k.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (k *Key) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshalYAML(value, "Key", k.UnmarshalText)
+}
+
+// MarshalYAML implements yaml.Marshaler with the base-62 encoded string
+func (s Shard) MarshalYAML() (interface{}, error) {
+	return s.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (s *Shard) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshalYAML(value, "Shard", s.UnmarshalText)
+}
+
+func unmarshalYAML(value *yaml.Node, typ string, unmarshalText func([]byte) error) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return fmt.Errorf("buid.%s: %w", typ, err)
+	}
+	if err := unmarshalText([]byte(s)); err != nil {
+		return fmt.Errorf("buid.%s: invalid value %q: %w", typ, s, err)
+	}
+	return nil
+}
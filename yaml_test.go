@@ -0,0 +1,56 @@
+package buid
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAML(t *testing.T) {
+	type config struct {
+		ID    ID    `yaml:"id"`
+		Shard Shard `yaml:"shard"`
+		Key   Key   `yaml:"key"`
+	}
+	id := NewProcess(2).NewID(1, time.Now())
+	c1 := config{ID: id, Shard: id.ShardPart(), Key: id.Key()}
+	buf, err := yaml.Marshal(c1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(buf), "id: "+id.String()+"\n") {
+		t.Fatalf("expect the base-62 string in %s", buf)
+	}
+	var c2 config
+	if err := yaml.Unmarshal(buf, &c2); err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 {
+		t.Fatalf("expect %v got %v", c1, c2)
+	}
+}
+
+func TestYAMLError(t *testing.T) {
+	id := NewProcess(2).NewID(1, time.Now())
+	for _, tc := range []struct {
+		doc string
+		v   interface{}
+		typ string
+	}{
+		{"id: abc", &struct{ ID ID }{}, "buid.ID"},
+		{"id: ab!c", &struct{ ID ID }{}, "buid.ID"},
+		{"id: [1, 2]", &struct{ ID ID }{}, "buid.ID"},
+		{"key: " + id.String(), &struct{ Key Key }{}, "buid.Key"},
+		{"shard: " + id.String(), &struct{ Shard Shard }{}, "buid.Shard"},
+	} {
+		err := yaml.Unmarshal([]byte(tc.doc), tc.v)
+		if err == nil {
+			t.Fatalf("expect error for %s", tc.doc)
+		}
+		if !strings.Contains(err.Error(), tc.typ) {
+			t.Fatalf("expect %s in %v", tc.typ, err)
+		}
+	}
+}